@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/pkg/codegen"
+)
+
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	var (
+		oldSpec       = fs.String("old", "", "Path to the previous spec revision (or first positional argument)")
+		newSpec       = fs.String("new", "", "Path to the new spec revision (or second positional argument)")
+		format        = fs.String("format", "text", "Output format: text|json")
+		allowBreaking = fs.Bool("allow-breaking", false, "Exit 0 even if breaking changes are found")
+	)
+	_ = fs.Parse(args)
+
+	rest := fs.Args()
+	if *oldSpec == "" && len(rest) > 0 {
+		*oldSpec, rest = rest[0], rest[1:]
+	}
+	if *newSpec == "" && len(rest) > 0 {
+		*newSpec, rest = rest[0], rest[1:]
+	}
+	if *oldSpec == "" || *newSpec == "" || len(rest) > 0 {
+		fmt.Fprintln(os.Stderr, "Error: usage: diff [flags] <old spec> <new spec>")
+		fs.Usage()
+		return 2
+	}
+
+	report, err := codegen.Diff(codegen.DiffOptions{OldSpecPath: *oldSpec, NewSpecPath: *newSpec})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	switch *format {
+	case "json":
+		out := struct {
+			*codegen.DiffReport
+			Breaking    int `json:"breaking"`
+			NonBreaking int `json:"nonBreaking"`
+		}{
+			DiffReport:  report,
+			Breaking:    len(report.Breaking()),
+			NonBreaking: len(report.Changes) - len(report.Breaking()),
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(out)
+	case "text":
+		for _, c := range report.Changes {
+			fmt.Println(c.String())
+		}
+		fmt.Println(report.Summary())
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (text|json)\n", *format)
+		return 2
+	}
+
+	if len(report.Breaking()) > 0 && !*allowBreaking {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/pkg/codegen"
+)
+
+// generateFlags are the flags shared by generate and watch.
+type generateFlags struct {
+	specPath, config, outDir, targets, baseURL, tplDir *string
+	check, verbose, force                              *bool
+	targetOpts                                         map[string]map[string]string
+}
+
+func addGenerateFlags(fs *flag.FlagSet) *generateFlags {
+	f := &generateFlags{
+		specPath:   fs.String("spec", "", "Path to openapi.yaml or openapi.json (required unless a config file is used)"),
+		config:     fs.String("config", "", "Path to openapi-rpc-codegen.yaml (default: found in the working directory when -spec is not set)"),
+		outDir:     fs.String("out", ".", "Output directory"),
+		targets:    fs.String("targets", "ts-wx", "Comma-separated targets: ts-wx,ts-mp,ts-fetch,ts-server,go-server,go-client,python-client,kotlin-client,swift-client,dart-client,raw-ir,plugin:<name>"),
+		baseURL:    fs.String("base-url", "", "Override servers[0].url"),
+		check:      fs.Bool("check", false, "Check-only mode: do not write; print a diff for every drifted file, list orphaned *.gen.* files and fail"),
+		verbose:    fs.Bool("v", false, "Verbose logs"),
+		force:      fs.Bool("force", false, "Overwrite or prune generated files even if they were edited by hand"),
+		tplDir:     fs.String("templates", "", "Directory with template overrides (e.g. server.go.tpl, client.ts.tpl)"),
+		targetOpts: map[string]map[string]string{},
+	}
+	setOpt := func(target, kv, s string) error {
+		k, v, ok := strings.Cut(kv, "=")
+		if target == "" || !ok {
+			return fmt.Errorf("expected <target>:<key>=<value>, got %q", s)
+		}
+		if f.targetOpts[target] == nil {
+			f.targetOpts[target] = map[string]string{}
+		}
+		f.targetOpts[target][k] = v
+		return nil
+	}
+	fs.Func("opt", "Target option as <target>:<key>=<value>, e.g. go-server:package=api (repeatable)", func(s string) error {
+		// split at the last ':' before '=' so "plugin:kotlin:package=x" works
+		lhs, _, _ := strings.Cut(s, "=")
+		i := strings.LastIndex(lhs, ":")
+		if i < 0 {
+			return fmt.Errorf("expected <target>:<key>=<value>, got %q", s)
+		}
+		return setOpt(s[:i], s[i+1:], s)
+	})
+	fs.Func("plugin-opt", "Plugin option as <plugin>:<key>=<value> (repeatable)", func(s string) error {
+		name, kv, _ := strings.Cut(s, ":")
+		return setOpt("plugin:"+name, kv, s)
+	})
+	return f
+}
+
+// runs returns the generation runs from the config file or the flags, plus the
+// config file path when one was used.
+func (f *generateFlags) runs() ([]codegen.Options, string, error) {
+	runs, configPath, err := generateRuns(*f.config, *f.specPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if runs == nil {
+		runs = []codegen.Options{{
+			SpecPath: *f.specPath,
+			OutDir:   *f.outDir,
+			BaseURL:  *f.baseURL,
+			Targets:  splitCSV(*f.targets),
+
+			TemplatesDir: *f.tplDir,
+
+			TargetOptions: f.targetOpts,
+		}}
+	}
+	for i := range runs {
+		runs[i].Check = *f.check
+		runs[i].Verbose = *f.verbose
+		runs[i].Force = *f.force
+	}
+	return runs, configPath, nil
+}
+
+func runGenerate(args []string) int {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	f := addGenerateFlags(fs)
+	_ = fs.Parse(args)
+
+	runs, _, err := f.runs()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		if *f.specPath == "" && *f.config == "" {
+			fs.Usage()
+		}
+		return 2
+	}
+	if !generateAll(runs) {
+		return 1
+	}
+	return 0
+}
+
+// generateAll runs every generation, printing diagnostics and errors to
+// stderr. It keeps going after check failures and reports whether all runs
+// succeeded.
+func generateAll(runs []codegen.Options) bool {
+	ok := true
+	for _, opts := range runs {
+		res, err := codegen.Generate(opts)
+		var checkErr *codegen.CheckError
+		if errors.As(err, &checkErr) {
+			// keep checking the remaining specs
+			fmt.Fprintln(os.Stderr, checkErr.Error())
+			ok = false
+			continue
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return false
+		}
+
+		for _, d := range res.Diagnostics {
+			fmt.Fprintln(os.Stderr, d.String())
+		}
+
+		if opts.Verbose {
+			fmt.Printf("%s: generated %d file(s)\n", opts.SpecPath, len(res.Files))
+			for _, f := range res.Files {
+				fmt.Println(" -", f)
+			}
+			for _, f := range res.Deleted {
+				fmt.Println(" - deleted", f)
+			}
+		}
+	}
+	return ok
+}
+
+// generateRuns loads the project config when -config is given or, without
+// -spec, when one is found in the working directory. It returns nil runs when
+// the command-line flags should be used instead.
+func generateRuns(configPath, specPath string) ([]codegen.Options, string, error) {
+	if configPath == "" && specPath == "" {
+		configPath = codegen.FindConfig(".")
+		if configPath == "" {
+			return nil, "", fmt.Errorf("-spec is required (or add %s)", codegen.ConfigFileNames[0])
+		}
+	}
+	if configPath == "" {
+		return nil, "", nil
+	}
+	cfg, err := codegen.LoadConfig(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return cfg.Options(), configPath, nil
+}
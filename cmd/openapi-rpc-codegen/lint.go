@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
+	"github.com/xxxbrian/openapi-rpc-codegen/pkg/codegen"
+)
+
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	var (
+		specPath  = fs.String("spec", "", "Path to openapi.yaml or openapi.json (required)")
+		baseURL   = fs.String("base-url", "", "Override servers[0].url")
+		format    = fs.String("format", "text", "Output format: text|json")
+		listRules = fs.Bool("list-rules", false, "List rule IDs with their default severity and exit")
+	)
+	severities := map[string]codegen.Severity{}
+	fs.Func("rule", "Override a rule severity as <rule>=off|warning|error (repeatable)", func(s string) error {
+		rule, sev, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("expected <rule>=<severity>, got %q", s)
+		}
+		parsed, err := diag.ParseSeverity(sev)
+		if err != nil {
+			return err
+		}
+		severities[strings.TrimSpace(rule)] = parsed
+		return nil
+	})
+	_ = fs.Parse(args)
+
+	if *listRules {
+		rules := codegen.LintRules()
+		ids := make([]string, 0, len(rules))
+		for id := range rules {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Printf("%-30s %s\n", id, rules[id])
+		}
+		return 0
+	}
+
+	if *specPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -spec is required")
+		fs.Usage()
+		return 2
+	}
+
+	diags, err := codegen.Lint(codegen.LintOptions{
+		SpecPath:   *specPath,
+		BaseURL:    *baseURL,
+		Severities: severities,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return 2
+	}
+
+	list := diag.List(diags)
+	switch *format {
+	case "json":
+		out := struct {
+			Diagnostics []codegen.Diagnostic `json:"diagnostics"`
+			Errors      int                  `json:"errors"`
+			Warnings    int                  `json:"warnings"`
+		}{
+			Diagnostics: append([]codegen.Diagnostic{}, diags...),
+			Errors:      list.Count(diag.SeverityError),
+			Warnings:    list.Count(diag.SeverityWarning),
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(out)
+	case "text":
+		for _, d := range diags {
+			fmt.Println(d.String())
+		}
+		fmt.Printf("%d error(s), %d warning(s)\n", list.Count(diag.SeverityError), list.Count(diag.SeverityWarning))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (text|json)\n", *format)
+		return 2
+	}
+
+	if list.HasErrors() {
+		return 1
+	}
+	return 0
+}
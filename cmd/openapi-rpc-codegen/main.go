@@ -1,12 +1,9 @@
 package main
 
 import (
-	"flag"
 	"fmt"
 	"os"
 	"strings"
-
-	"github.com/xxxbrian/openapi-rpc-codegen/pkg/codegen"
 )
 
 func splitCSV(s string) []string {
@@ -21,41 +18,25 @@ func splitCSV(s string) []string {
 }
 
 func main() {
-	var (
-		specPath = flag.String("spec", "", "Path to openapi.yaml or openapi.json (required)")
-		outDir   = flag.String("out", ".", "Output directory")
-		targets  = flag.String("targets", "ts-wx", "Comma-separated targets: ts-wx,go-server")
-		baseURL  = flag.String("base-url", "", "Override servers[0].url")
-		check    = flag.Bool("check", false, "Check-only mode: do not write, fail if output differs")
-		verbose  = flag.Bool("v", false, "Verbose logs")
-	)
-	flag.Parse()
-
-	if *specPath == "" {
-		fmt.Fprintln(os.Stderr, "Error: -spec is required")
-		flag.Usage()
-		os.Exit(2)
-	}
-
-	opts := codegen.Options{
-		SpecPath: *specPath,
-		OutDir:   *outDir,
-		BaseURL:  *baseURL,
-		Check:    *check,
-		Verbose:  *verbose,
-		Targets:  splitCSV(*targets),
+	args := os.Args[1:]
+	cmd := "generate"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd, args = args[0], args[1:]
 	}
 
-	res, err := codegen.Generate(opts)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err.Error())
-		os.Exit(1)
-	}
-
-	if opts.Verbose {
-		fmt.Printf("Generated %d file(s)\n", len(res.Files))
-		for _, f := range res.Files {
-			fmt.Println(" -", f)
-		}
+	switch cmd {
+	case "generate":
+		os.Exit(runGenerate(args))
+	case "lint":
+		os.Exit(runLint(args))
+	case "diff":
+		os.Exit(runDiff(args))
+	case "scaffold", "init":
+		os.Exit(runScaffold(args))
+	case "watch":
+		os.Exit(runWatch(args))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q (commands: generate, lint, watch, scaffold, diff)\n", cmd)
+		os.Exit(2)
 	}
 }
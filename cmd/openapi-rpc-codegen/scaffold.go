@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/pkg/codegen"
+)
+
+func runScaffold(args []string) int {
+	fs := flag.NewFlagSet("scaffold", flag.ExitOnError)
+	var (
+		specPath   = fs.String("spec", "", "Path to openapi.yaml or openapi.json (required)")
+		baseURL    = fs.String("base-url", "", "Override servers[0].url")
+		dir        = fs.String("out", "go-server", "go-server output directory to add *_impl.go stubs to")
+		pkg        = fs.String("package", "", "Go package name (default: the package in -out, else server)")
+		mainPath   = fs.String("main", "", "Also create this main.go wiring RegisterRoutes (skipped if it exists)")
+		importPath = fs.String("import", "", "Import path of -out for main.go (default: derived from go.mod)")
+		router     = fs.String("router", "", "Router for main.go: chi|stdlib|gin|echo (default: the one RegisterRoutes in -out uses, else chi)")
+	)
+	_ = fs.Parse(args)
+
+	if *specPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: -spec is required")
+		fs.Usage()
+		return 2
+	}
+
+	res, err := codegen.Scaffold(codegen.ScaffoldOptions{
+		SpecPath:   *specPath,
+		BaseURL:    *baseURL,
+		Dir:        *dir,
+		Package:    *pkg,
+		MainPath:   *mainPath,
+		ImportPath: *importPath,
+		Router:     *router,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	for _, d := range res.Diagnostics {
+		fmt.Fprintln(os.Stderr, d.String())
+	}
+	for _, f := range res.Created {
+		fmt.Println("created", f)
+	}
+	for _, f := range res.Updated {
+		fmt.Println("updated", f)
+	}
+	for _, n := range res.Notes {
+		fmt.Println("note:", n)
+	}
+	if len(res.Created)+len(res.Updated) == 0 {
+		fmt.Println("nothing to do: every operation is implemented")
+	}
+	return 0
+}
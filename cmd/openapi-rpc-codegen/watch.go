@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/pkg/codegen"
+)
+
+func runWatch(args []string) int {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	f := addGenerateFlags(flags)
+	interval := flags.Duration("interval", 500*time.Millisecond, "Polling interval")
+	debounce := flags.Duration("debounce", 300*time.Millisecond, "Wait until files are unchanged for this long before regenerating")
+	_ = flags.Parse(args)
+
+	if *f.check {
+		fmt.Fprintln(os.Stderr, "Error: watch does not support -check")
+		return 2
+	}
+	if *interval <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: -interval must be positive, got %s\n", *interval)
+		flags.Usage()
+		return 2
+	}
+	if *debounce < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -debounce must not be negative, got %s\n", *debounce)
+		flags.Usage()
+		return 2
+	}
+	if *f.specPath == "" && *f.config == "" && codegen.FindConfig(".") == "" {
+		fmt.Fprintf(os.Stderr, "Error: -spec is required (or add %s)\n", codegen.ConfigFileNames[0])
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		watched := watchGenerate(f)
+		fmt.Fprintf(os.Stderr, "watching %d file(s) for changes (Ctrl-C to stop)\n", len(watched))
+		if !waitForChange(ctx, watched, *interval, *debounce) {
+			return 0
+		}
+	}
+}
+
+// watchGenerate runs one generation, reporting problems without exiting, and
+// returns the files whose changes should trigger the next one.
+func watchGenerate(f *generateFlags) []string {
+	start := time.Now()
+	runs, configPath, err := f.runs()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		// watch whatever failed to load so fixing it triggers a rerun
+		return nonEmpty(*f.config, codegen.FindConfig("."), *f.specPath)
+	}
+
+	ok := generateAll(runs)
+	status := "done"
+	if !ok {
+		status = "failed"
+	}
+	fmt.Fprintf(os.Stderr, "[%s] generation %s in %s\n", start.Format("15:04:05"), status, time.Since(start).Round(time.Millisecond))
+
+	watched := nonEmpty(configPath)
+	for _, opts := range runs {
+		files, err := codegen.SpecFiles(opts.SpecPath)
+		if err != nil {
+			files = []string{opts.SpecPath}
+		}
+		watched = append(watched, files...)
+		watched = append(watched, templateFiles(opts.TemplatesDir)...)
+	}
+	return watched
+}
+
+func nonEmpty(paths ...string) []string {
+	var out []string
+	for _, p := range paths {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func templateFiles(dir string) []string {
+	if dir == "" {
+		return nil
+	}
+	files := []string{dir}
+	_ = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files
+}
+
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func stampFiles(paths []string) map[string]fileStamp {
+	out := make(map[string]fileStamp, len(paths))
+	for _, p := range paths {
+		st, err := os.Stat(p)
+		if err != nil {
+			out[p] = fileStamp{}
+			continue
+		}
+		out[p] = fileStamp{exists: true, size: st.Size(), modTime: st.ModTime()}
+	}
+	return out
+}
+
+func sameStamps(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for p, s := range a {
+		if b[p] != s {
+			return false
+		}
+	}
+	return true
+}
+
+// waitForChange polls paths until one changes and then stays unchanged for
+// debounce (editors often write a file in several steps). It returns false
+// when ctx is canceled.
+func waitForChange(ctx context.Context, paths []string, interval, debounce time.Duration) bool {
+	base := stampFiles(paths)
+	last := base
+	var changedAt time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case now := <-ticker.C:
+			cur := stampFiles(paths)
+			if !sameStamps(cur, last) {
+				last, changedAt = cur, now
+				continue
+			}
+			if !changedAt.IsZero() && !sameStamps(cur, base) && now.Sub(changedAt) >= debounce {
+				return true
+			}
+		}
+	}
+}
@@ -2,7 +2,10 @@ module github.com/xxxbrian/openapi-rpc-codegen
 
 go 1.25.4
 
-require github.com/getkin/kin-openapi v0.133.0
+require (
+	github.com/getkin/kin-openapi v0.133.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/go-openapi/jsonpointer v0.21.0 // indirect
@@ -14,5 +17,4 @@ require (
 	github.com/oasdiff/yaml3 v0.0.0-20250309153720-d2182401db90 // indirect
 	github.com/perimeterx/marshmallow v1.1.5 // indirect
 	github.com/woodsbury/decimal128 v1.3.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,75 @@
+package diag
+
+import "fmt"
+
+// Collector accumulates diagnostics, applying per-rule severity overrides and
+// resolving pointers against the spec source when one is attached.
+type Collector struct {
+	overrides map[string]Severity
+	source    *Source
+	diags     List
+}
+
+func NewCollector() *Collector {
+	return &Collector{overrides: map[string]Severity{}}
+}
+
+// SetSeverity overrides the default severity of a rule.
+func (c *Collector) SetSeverity(rule string, sev Severity) error {
+	if _, ok := defaultSeverities[rule]; !ok {
+		return fmt.Errorf("unknown rule %q", rule)
+	}
+	c.overrides[rule] = sev
+	return nil
+}
+
+// SetSource attaches the spec source used to resolve line/column positions.
+func (c *Collector) SetSource(src *Source) {
+	c.source = src
+}
+
+func (c *Collector) Severity(rule string) Severity {
+	if sev, ok := c.overrides[rule]; ok {
+		return sev
+	}
+	if sev, ok := defaultSeverities[rule]; ok {
+		return sev
+	}
+	return SeverityError
+}
+
+// Reportf records a violation of rule at pointer. Rules configured as off are
+// dropped.
+func (c *Collector) Reportf(rule, pointer, format string, args ...any) {
+	sev := c.Severity(rule)
+	if sev == SeverityOff {
+		return
+	}
+	d := Diagnostic{
+		Severity: sev,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+		Pointer:  pointer,
+	}
+	if c.source != nil {
+		d.File = c.source.Path
+		d.Line, d.Column = c.source.Locate(pointer)
+	}
+	c.diags = append(c.diags, d)
+}
+
+func (c *Collector) Diagnostics() List {
+	return c.diags
+}
+
+func (c *Collector) HasErrors() bool {
+	return c.diags.HasErrors()
+}
+
+// Err returns all diagnostics as an error if any of them is an error, nil otherwise.
+func (c *Collector) Err() error {
+	if !c.diags.HasErrors() {
+		return nil
+	}
+	return c.diags
+}
@@ -0,0 +1,112 @@
+// Package diag collects lint-style diagnostics produced while loading and
+// normalizing an OpenAPI document.
+package diag
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+func ParseSeverity(s string) (Severity, error) {
+	switch Severity(strings.ToLower(strings.TrimSpace(s))) {
+	case SeverityOff:
+		return SeverityOff, nil
+	case SeverityWarning, "warn":
+		return SeverityWarning, nil
+	case SeverityError:
+		return SeverityError, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q (must be off|warning|error)", s)
+	}
+}
+
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+
+	// Pointer is a JSON pointer (RFC 6901) into the spec document.
+	Pointer string `json:"pointer"`
+
+	// File/Line/Column are filled when the pointer resolves in the spec source.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+// String renders the diagnostic as "file:line:col: severity: message [rule]".
+func (d Diagnostic) String() string {
+	var b strings.Builder
+	if d.File != "" {
+		b.WriteString(d.File)
+		if d.Line > 0 {
+			fmt.Fprintf(&b, ":%d:%d", d.Line, d.Column)
+		}
+		b.WriteString(": ")
+	}
+	fmt.Fprintf(&b, "%s: %s [%s]", d.Severity, d.Message, d.Rule)
+	if d.Pointer != "" {
+		fmt.Fprintf(&b, " (#%s)", d.Pointer)
+	}
+	return b.String()
+}
+
+// List is a set of diagnostics. It implements error so a failed run can
+// return every violation at once.
+type List []Diagnostic
+
+func (l List) Error() string {
+	lines := make([]string, 0, len(l))
+	for _, d := range l {
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (l List) HasErrors() bool {
+	return l.Count(SeverityError) > 0
+}
+
+func (l List) Count(sev Severity) int {
+	n := 0
+	for _, d := range l {
+		if d.Severity == sev {
+			n++
+		}
+	}
+	return n
+}
+
+// Pointer builds a JSON pointer from raw (unescaped) reference tokens.
+func Pointer(base string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, t := range tokens {
+		b.WriteByte('/')
+		t = strings.ReplaceAll(t, "~", "~0")
+		t = strings.ReplaceAll(t, "/", "~1")
+		b.WriteString(t)
+	}
+	return b.String()
+}
+
+// splitPointer returns the unescaped reference tokens of a JSON pointer.
+func splitPointer(p string) []string {
+	if p == "" {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	for i, t := range parts {
+		t = strings.ReplaceAll(t, "~1", "/")
+		parts[i] = strings.ReplaceAll(t, "~0", "~")
+	}
+	return parts
+}
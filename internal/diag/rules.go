@@ -0,0 +1,83 @@
+package diag
+
+import "sort"
+
+// Rule IDs. Each rule has a default severity which can be overridden per run.
+const (
+	RuleSpecLoad    = "spec-load"
+	RuleSpecInvalid = "spec-invalid"
+	RuleServersURL  = "servers-url"
+	RuleErrorCodes  = "error-codes"
+	RuleLimits      = "limits"
+
+	RuleOperationIDMissing   = "operation-id-missing"
+	RuleOperationIDInvalid   = "operation-id-invalid"
+	RuleOperationIDDuplicate = "operation-id-duplicate"
+	RuleOperationTagMissing  = "operation-tag-missing"
+	RuleMethodUnsupported    = "method-unsupported"
+	RuleGetRequestBody       = "get-request-body"
+
+	RuleParameterInvalid  = "parameter-invalid"
+	RuleParameterLocation = "parameter-location"
+	RuleParameterSchema   = "parameter-schema"
+
+	RuleRequestBodyContent = "request-body-content"
+	RuleResponseStatus     = "response-status"
+	RuleResponseContent    = "response-content"
+
+	RuleComponentSchema                 = "component-schema"
+	RuleSchemaRef                       = "schema-ref"
+	RuleSchemaCombinator                = "schema-combinator"
+	RuleSchemaAdditionalProperty        = "schema-additional-properties"
+	RuleSchemaAdditionalPropertyIgnored = "schema-additional-properties-ignored"
+	RuleSchemaEnum                      = "schema-enum"
+	RuleSchemaType                      = "schema-type"
+	RuleSchemaArrayItems                = "schema-array-items"
+)
+
+var defaultSeverities = map[string]Severity{
+	RuleSpecLoad:    SeverityError,
+	RuleSpecInvalid: SeverityError,
+	RuleServersURL:  SeverityError,
+	RuleErrorCodes:  SeverityError,
+	RuleLimits:      SeverityError,
+
+	RuleOperationIDMissing:   SeverityError,
+	RuleOperationIDInvalid:   SeverityError,
+	RuleOperationIDDuplicate: SeverityError,
+	RuleOperationTagMissing:  SeverityWarning,
+	RuleMethodUnsupported:    SeverityError,
+	RuleGetRequestBody:       SeverityError,
+
+	RuleParameterInvalid:  SeverityError,
+	RuleParameterLocation: SeverityError,
+	RuleParameterSchema:   SeverityError,
+
+	RuleRequestBodyContent: SeverityError,
+	RuleResponseStatus:     SeverityError,
+	RuleResponseContent:    SeverityError,
+
+	RuleComponentSchema:                 SeverityError,
+	RuleSchemaRef:                       SeverityError,
+	RuleSchemaCombinator:                SeverityError,
+	RuleSchemaAdditionalProperty:        SeverityError,
+	RuleSchemaAdditionalPropertyIgnored: SeverityWarning,
+	RuleSchemaEnum:                      SeverityError,
+	RuleSchemaType:                      SeverityError,
+	RuleSchemaArrayItems:                SeverityError,
+}
+
+// Rules returns every known rule ID in sorted order.
+func Rules() []string {
+	out := make([]string, 0, len(defaultSeverities))
+	for r := range defaultSeverities {
+		out = append(out, r)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func DefaultSeverity(rule string) (Severity, bool) {
+	sev, ok := defaultSeverities[rule]
+	return sev, ok
+}
@@ -0,0 +1,64 @@
+package diag
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source maps JSON pointers to line/column positions in a YAML or JSON file.
+type Source struct {
+	Path string
+	root *yaml.Node
+}
+
+func ParseSource(path string, data []byte) (*Source, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	root := &doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	return &Source{Path: path, root: root}, nil
+}
+
+// Locate returns the position of the node addressed by pointer. When the
+// pointer leaves the document (e.g. a missing key), the position of the
+// deepest existing ancestor is returned instead.
+func (s *Source) Locate(pointer string) (line, col int) {
+	if s == nil || s.root == nil {
+		return 0, 0
+	}
+	n := s.root
+	for _, tok := range splitPointer(pointer) {
+		next := child(n, tok)
+		if next == nil {
+			break
+		}
+		n = next
+	}
+	return n.Line, n.Column
+}
+
+func child(n *yaml.Node, tok string) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == tok {
+				return n.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(tok)
+		if err == nil && idx >= 0 && idx < len(n.Content) {
+			return n.Content[idx]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package emit
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/dart"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/client"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/kotlin"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/plugin"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/python"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/rawir"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/swift"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/ts/fetch"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/ts/mp"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/ts/node"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/ts/wx"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+var (
+	goIdentRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	tsIdentRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+	goImportRe  = regexp.MustCompile(`^[A-Za-z0-9_.~+-]+(/[A-Za-z0-9_.~+-]+)*$`)
+	ktPackageRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+)
+
+func init() {
+	Register(rawIREmitter{})
+	Register(tsWxEmitter{})
+	Register(goServerEmitter{})
+	Register(goClientEmitter{})
+	Register(tsFetchEmitter{})
+	Register(tsMpEmitter{})
+	Register(pythonClientEmitter{})
+	Register(kotlinClientEmitter{})
+	Register(swiftClientEmitter{})
+	Register(dartClientEmitter{})
+	Register(tsServerEmitter{})
+}
+
+type rawIREmitter struct{}
+
+func (rawIREmitter) Name() string { return "raw-ir" }
+
+func (rawIREmitter) ValidateOptions(opts map[string]string) error {
+	return knownOptions(opts)
+}
+
+func (rawIREmitter) Emit(spec *ir.Spec, _ EmitOptions) ([]common.File, error) {
+	return rawir.Emit(spec)
+}
+
+type tsWxEmitter struct{}
+
+func (tsWxEmitter) Name() string { return "ts-wx" }
+
+func (tsWxEmitter) ValidateOptions(opts map[string]string) error {
+	return validateTSOptions(opts)
+}
+
+func (tsWxEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return emitTS(spec, opt, mpTransport("ts-wx", "wx"))
+}
+
+// tsMpEmitter is ts-wx for any supported mini-program platform, selected with
+// the platform option (default wx).
+type tsMpEmitter struct{}
+
+func (tsMpEmitter) Name() string { return "ts-mp" }
+
+func (tsMpEmitter) ValidateOptions(opts map[string]string) error {
+	if err := validateTSOptions(opts, "platform"); err != nil {
+		return err
+	}
+	if p, ok := opts["platform"]; ok && !mp.IsPlatform(p) {
+		return fmt.Errorf("unknown platform %q (known: %s)", p, strings.Join(mp.Platforms, ", "))
+	}
+	return nil
+}
+
+func (tsMpEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return emitTS(spec, opt, mpTransport("ts-mp", opt.Options["platform"]))
+}
+
+func mpTransport(target, platform string) func(*ir.Spec, wx.EmitOptions) ([]common.File, error) {
+	return func(spec *ir.Spec, opt wx.EmitOptions) ([]common.File, error) {
+		return mp.EmitTransport(spec, mp.EmitOptions{
+			Target:       target,
+			Platform:     platform,
+			TemplatesDir: opt.TemplatesDir,
+		})
+	}
+}
+
+type tsFetchEmitter struct{}
+
+func (tsFetchEmitter) Name() string { return "ts-fetch" }
+
+func (tsFetchEmitter) ValidateOptions(opts map[string]string) error {
+	return validateTSOptions(opts)
+}
+
+func (tsFetchEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return emitTS(spec, opt, fetch.EmitTransport)
+}
+
+func validateTSOptions(opts map[string]string, extra ...string) error {
+	if err := knownOptions(opts, append([]string{"clientFactory"}, extra...)...); err != nil {
+		return err
+	}
+	if f, ok := opts["clientFactory"]; ok && !tsIdentRe.MatchString(f) {
+		return fmt.Errorf("clientFactory %q is not a valid TypeScript identifier", f)
+	}
+	return nil
+}
+
+// emitTS renders the shared types.gen.ts and client.gen.ts around a
+// target-specific transport.ts.
+func emitTS(spec *ir.Spec, opt EmitOptions, transport func(*ir.Spec, wx.EmitOptions) ([]common.File, error)) ([]common.File, error) {
+	var files []common.File
+	for _, fn := range []func(*ir.Spec, wx.EmitOptions) ([]common.File, error){
+		wx.EmitTypes,
+		transport,
+		wx.EmitClient,
+	} {
+		fs, err := fn(spec, wx.EmitOptions{
+			ClientFactory: opt.Options["clientFactory"],
+			TemplatesDir:  opt.TemplatesDir,
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fs...)
+	}
+	return files, nil
+}
+
+type goServerEmitter struct{}
+
+func (goServerEmitter) Name() string { return "go-server" }
+
+func (goServerEmitter) ValidateOptions(opts map[string]string) error {
+	if err := knownOptions(opts, "package", "router", "errors"); err != nil {
+		return err
+	}
+	if p, ok := opts["package"]; ok && !goIdentRe.MatchString(p) {
+		return fmt.Errorf("package %q is not a valid Go package name", p)
+	}
+	if r, ok := opts["router"]; ok && !slices.Contains(server.Routers, r) {
+		return fmt.Errorf("unknown router %q (known: %s)", r, strings.Join(server.Routers, ", "))
+	}
+	if e, ok := opts["errors"]; ok && !slices.Contains(server.ErrorFormats, e) {
+		return fmt.Errorf("unknown errors format %q (known: %s)", e, strings.Join(server.ErrorFormats, ", "))
+	}
+	return nil
+}
+
+func (goServerEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return server.Emit(spec, server.EmitOptions{
+		Package:      opt.Options["package"],
+		Router:       opt.Options["router"],
+		Errors:       opt.Options["errors"],
+		TemplatesDir: opt.TemplatesDir,
+	})
+}
+
+type goClientEmitter struct{}
+
+func (goClientEmitter) Name() string { return "go-client" }
+
+func (goClientEmitter) ValidateOptions(opts map[string]string) error {
+	if err := knownOptions(opts, "package", "typesImport"); err != nil {
+		return err
+	}
+	if p, ok := opts["package"]; ok && !goIdentRe.MatchString(p) {
+		return fmt.Errorf("package %q is not a valid Go package name", p)
+	}
+	if p, ok := opts["typesImport"]; ok && !goImportRe.MatchString(p) {
+		return fmt.Errorf("typesImport %q is not a valid Go import path", p)
+	}
+	return nil
+}
+
+func (goClientEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return client.Emit(spec, client.EmitOptions{
+		Package:      opt.Options["package"],
+		TypesImport:  opt.Options["typesImport"],
+		TemplatesDir: opt.TemplatesDir,
+	})
+}
+
+type pythonClientEmitter struct{}
+
+func (pythonClientEmitter) Name() string { return "python-client" }
+
+func (pythonClientEmitter) ValidateOptions(opts map[string]string) error {
+	return knownOptions(opts)
+}
+
+func (pythonClientEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return python.Emit(spec, python.EmitOptions{TemplatesDir: opt.TemplatesDir})
+}
+
+type kotlinClientEmitter struct{}
+
+func (kotlinClientEmitter) Name() string { return "kotlin-client" }
+
+func (kotlinClientEmitter) ValidateOptions(opts map[string]string) error {
+	if err := knownOptions(opts, "package"); err != nil {
+		return err
+	}
+	if p, ok := opts["package"]; ok && !ktPackageRe.MatchString(p) {
+		return fmt.Errorf("package %q is not a valid Kotlin package name", p)
+	}
+	return nil
+}
+
+func (kotlinClientEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return kotlin.Emit(spec, kotlin.EmitOptions{
+		Package:      opt.Options["package"],
+		TemplatesDir: opt.TemplatesDir,
+	})
+}
+
+type swiftClientEmitter struct{}
+
+func (swiftClientEmitter) Name() string { return "swift-client" }
+
+func (swiftClientEmitter) ValidateOptions(opts map[string]string) error {
+	return knownOptions(opts)
+}
+
+func (swiftClientEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return swift.Emit(spec, swift.EmitOptions{TemplatesDir: opt.TemplatesDir})
+}
+
+type dartClientEmitter struct{}
+
+func (dartClientEmitter) Name() string { return "dart-client" }
+
+func (dartClientEmitter) ValidateOptions(opts map[string]string) error {
+	return knownOptions(opts)
+}
+
+func (dartClientEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return dart.Emit(spec, dart.EmitOptions{TemplatesDir: opt.TemplatesDir})
+}
+
+type tsServerEmitter struct{}
+
+func (tsServerEmitter) Name() string { return "ts-server" }
+
+func (tsServerEmitter) ValidateOptions(opts map[string]string) error {
+	return knownOptions(opts)
+}
+
+func (tsServerEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	types, err := wx.EmitTypes(spec, wx.EmitOptions{TemplatesDir: opt.TemplatesDir})
+	if err != nil {
+		return nil, err
+	}
+	srv, err := node.EmitServer(spec, node.EmitOptions{TemplatesDir: opt.TemplatesDir})
+	if err != nil {
+		return nil, err
+	}
+	return append(types, srv...), nil
+}
+
+type pluginEmitter struct {
+	name string
+}
+
+func (e pluginEmitter) Name() string { return "plugin:" + e.name }
+
+func (e pluginEmitter) Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	return plugin.Emit(spec, plugin.EmitOptions{
+		Name:    e.name,
+		Options: opt.Options,
+	})
+}
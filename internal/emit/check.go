@@ -0,0 +1,184 @@
+package emit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+)
+
+type DriftKind string
+
+const (
+	DriftChanged  DriftKind = "changed"
+	DriftMissing  DriftKind = "missing"
+	DriftOrphaned DriftKind = "orphaned"
+)
+
+// Drift is one file that doesn't match what generation would produce.
+type Drift struct {
+	Path string
+	Kind DriftKind
+	Diff string // unified diff; empty for orphaned files
+}
+
+// CheckError is returned by Dispatch in check mode when any output drifted.
+type CheckError struct {
+	Drifts []Drift
+}
+
+func (e *CheckError) Count(kind DriftKind) int {
+	n := 0
+	for _, d := range e.Drifts {
+		if d.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func (e *CheckError) Error() string {
+	var b strings.Builder
+	for _, d := range e.Drifts {
+		if d.Kind == DriftOrphaned {
+			fmt.Fprintf(&b, "orphaned generated file: %s\n", d.Path)
+			continue
+		}
+		b.WriteString(d.Diff)
+	}
+	fmt.Fprintf(&b, "check failed: %d changed, %d missing, %d orphaned file(s)",
+		e.Count(DriftChanged), e.Count(DriftMissing), e.Count(DriftOrphaned))
+	return b.String()
+}
+
+type renderedFile struct {
+	dir     string // target output dir
+	rel     string // slash-separated path relative to dir
+	path    string // resolved output path
+	content []byte
+}
+
+// check compares every rendered file with the disk and looks for orphaned
+// *.gen.* files next to them and in the target directories.
+func check(files []renderedFile, targetDirs []string) error {
+	var drifts []Drift
+	produced := map[string]bool{}
+	dirs := map[string]bool{}
+	for _, d := range targetDirs {
+		dirs[filepath.Clean(d)] = true
+	}
+
+	for _, f := range files {
+		produced[filepath.Clean(f.path)] = true
+		dirs[filepath.Dir(filepath.Clean(f.path))] = true
+
+		diff, exists, err := common.DiffFile(f.path, f.content)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			continue
+		}
+		kind := DriftChanged
+		if !exists {
+			kind = DriftMissing
+		}
+		drifts = append(drifts, Drift{Path: f.path, Kind: kind, Diff: diff})
+	}
+
+	orphans, err := findOrphans(dirs, produced)
+	if err != nil {
+		return err
+	}
+	manifestOrphans, err := findManifestOrphans(targetDirs, produced)
+	if err != nil {
+		return err
+	}
+	orphans = mergeSorted(orphans, manifestOrphans)
+	for _, p := range orphans {
+		drifts = append(drifts, Drift{Path: p, Kind: DriftOrphaned})
+	}
+
+	if len(drifts) == 0 {
+		return nil
+	}
+	return &CheckError{Drifts: drifts}
+}
+
+// findOrphans lists *.gen.* files in dirs (non-recursive) that were not produced.
+func findOrphans(dirs, produced map[string]bool) ([]string, error) {
+	var out []string
+	for dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read output dir: %w", err)
+		}
+		for _, e := range entries {
+			p := filepath.Join(dir, e.Name())
+			if e.IsDir() || !IsGeneratedName(e.Name()) || produced[p] {
+				continue
+			}
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// findManifestOrphans lists files recorded in the target dirs' manifests that
+// still exist but are no longer produced.
+func findManifestOrphans(targetDirs []string, produced map[string]bool) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, dir := range targetDirs {
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		m, err := readManifest(dir)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			continue
+		}
+		for rel := range m.Files {
+			p := filepath.Join(dir, filepath.FromSlash(rel))
+			if produced[p] {
+				continue
+			}
+			if _, err := os.Stat(p); err == nil {
+				out = append(out, p)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func mergeSorted(a, b []string) []string {
+	set := map[string]bool{}
+	for _, s := range a {
+		set[s] = true
+	}
+	for _, s := range b {
+		set[s] = true
+	}
+	out := make([]string, 0, len(set))
+	for s := range set {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IsGeneratedName reports whether a file name follows the *.gen.* convention.
+func IsGeneratedName(name string) bool {
+	return strings.Contains(name, ".gen.")
+}
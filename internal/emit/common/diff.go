@@ -0,0 +1,218 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiffEdits bounds the Myers search; beyond it the diff falls back to
+// replacing the whole file, which keeps memory bounded on unrelated inputs.
+const maxDiffEdits = 4000
+
+type editOp int
+
+const (
+	editEqual editOp = iota
+	editDelete
+	editInsert
+)
+
+type edit struct {
+	op   editOp
+	a, b int // line index in a (equal/delete) and b (equal/insert)
+}
+
+// UnifiedDiff renders a unified diff (3 lines of context) from oldText to
+// newText. It returns "" when both are equal.
+func UnifiedDiff(oldName, newName string, oldText, newText []byte) string {
+	a := splitLines(string(oldText))
+	b := splitLines(string(newText))
+	edits := diffLines(a, b)
+
+	const context = 3
+	var out strings.Builder
+	for i := 0; i < len(edits); {
+		// find the next change
+		for i < len(edits) && edits[i].op == editEqual {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+
+		start := max(i-context, 0)
+		end := i
+		for end < len(edits) {
+			if edits[end].op != editEqual {
+				end++
+				continue
+			}
+			// stop once a run of equal lines is long enough to split hunks
+			run := end
+			for run < len(edits) && edits[run].op == editEqual {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				end = min(end+context, len(edits))
+				break
+			}
+			end = run
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		writeHunk(&out, a, b, edits[start:end])
+		i = end
+	}
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, a, b []string, hunk []edit) {
+	aStart, bStart := -1, -1
+	aLen, bLen := 0, 0
+	for _, e := range hunk {
+		if e.op != editInsert {
+			if aStart < 0 {
+				aStart = e.a
+			}
+			aLen++
+		}
+		if e.op != editDelete {
+			if bStart < 0 {
+				bStart = e.b
+			}
+			bLen++
+		}
+	}
+	// empty side: unified diff addresses the line before the hunk
+	if aStart < 0 {
+		aStart = hunkAnchor(hunk, true)
+	}
+	if bStart < 0 {
+		bStart = hunkAnchor(hunk, false)
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+	for _, e := range hunk {
+		switch e.op {
+		case editEqual:
+			out.WriteString(" " + a[e.a] + "\n")
+		case editDelete:
+			out.WriteString("-" + a[e.a] + "\n")
+		case editInsert:
+			out.WriteString("+" + b[e.b] + "\n")
+		}
+	}
+}
+
+func hunkAnchor(hunk []edit, sideA bool) int {
+	if sideA {
+		return hunk[0].a - 1
+	}
+	return hunk[0].b - 1
+}
+
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start+1)
+	}
+	if n == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a shortest edit script with Myers' O(ND) algorithm.
+func diffLines(a, b []string) []edit {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxDiffEdits)
+
+	// trace[d][k+d] is the furthest x on diagonal k before round d
+	var trace [][]int
+	v := map[int]int{1: 0}
+	found := -1
+	for d := 0; d <= limit && found < 0; d++ {
+		snap := make([]int, 2*d+1)
+		for k := -d; k <= d; k++ {
+			snap[k+d] = v[k]
+		}
+		trace = append(trace, snap)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1] < v[k+1]) {
+				x = v[k+1]
+			} else {
+				x = v[k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k] = x
+			if x >= n && y >= m {
+				found = d
+				break
+			}
+		}
+	}
+	if found < 0 {
+		return replaceAll(n, m)
+	}
+
+	var edits []edit
+	x, y := n, m
+	for d := found; d >= 0; d-- {
+		snap := trace[d]
+		at := func(k int) int { return snap[k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = at(prevK)
+		}
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{op: editEqual, a: x, b: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				y--
+				edits = append(edits, edit{op: editInsert, a: x, b: y})
+			} else {
+				x--
+				edits = append(edits, edit{op: editDelete, a: x, b: y})
+			}
+		}
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+func replaceAll(n, m int) []edit {
+	edits := make([]edit, 0, n+m)
+	for i := 0; i < n; i++ {
+		edits = append(edits, edit{op: editDelete, a: i})
+	}
+	for j := 0; j < m; j++ {
+		edits = append(edits, edit{op: editInsert, a: n, b: j})
+	}
+	return edits
+}
@@ -6,51 +6,41 @@ import (
 	"go/format"
 	"os"
 	"path"
-	"path/filepath"
 )
 
-type WriteOptions struct {
-	Check bool
-}
-
-func WriteFile(p string, data []byte, opt WriteOptions) (wrote bool, err error) {
-	formattedData, err := formatCode(p, data)
-	if err != nil {
-		fmt.Printf("warning: code format failed for %s: %v\n", p, err)
-	}
-
-	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
-		return false, fmt.Errorf("mkdir: %w", err)
-	}
+// DiffFile returns a unified diff from p on disk to data as it would be
+// written; "" means p is up to date. A missing file diffs as empty.
+func DiffFile(p string, data []byte) (diff string, exists bool, err error) {
+	formattedData := Prepare(p, data)
 
 	existing, readErr := os.ReadFile(p)
-	if readErr == nil {
-		if bytes.Equal(existing, formattedData) {
-			// no change
-			return false, nil
-		}
-		if opt.Check {
-			return false, fmt.Errorf("check failed: %s differs", p)
-		}
-	} else if !os.IsNotExist(readErr) {
-		return false, fmt.Errorf("read existing: %w", readErr)
+	if readErr != nil && !os.IsNotExist(readErr) {
+		return "", false, fmt.Errorf("read existing: %w", readErr)
 	}
-
-	if opt.Check {
-		// file doesn't exist or differs, but check mode forbids writing
-		return false, fmt.Errorf("check failed: %s would be written/changed", p)
+	exists = readErr == nil
+	if exists && bytes.Equal(existing, formattedData) {
+		return "", true, nil
 	}
 
-	tmp := p + ".tmp"
-	if err := os.WriteFile(tmp, formattedData, 0o644); err != nil {
-		return false, fmt.Errorf("write tmp: %w", err)
+	oldName := p
+	if !exists {
+		oldName = "/dev/null"
 	}
-	if err := os.Rename(tmp, p); err != nil {
-		_ = os.Remove(tmp)
-		return false, fmt.Errorf("rename tmp: %w", err)
+	newName := p + " (generated)"
+	diff = UnifiedDiff(oldName, newName, existing, formattedData)
+	if diff == "" {
+		diff = fmt.Sprintf("--- %s\n+++ %s\n\\ files differ only in trailing newlines\n", oldName, newName)
 	}
+	return diff, exists, nil
+}
 
-	return true, nil
+// Prepare returns data as it is written to p (gofmt'ed for .go files).
+func Prepare(p string, data []byte) []byte {
+	formattedData, err := formatCode(p, data)
+	if err != nil {
+		fmt.Printf("warning: code format failed for %s: %v\n", p, err)
+	}
+	return formattedData
 }
 
 func formatCode(p string, data []byte) ([]byte, error) {
@@ -0,0 +1,8 @@
+package common
+
+// File is a rendered output file. Path is slash-separated and relative to the
+// target's output directory.
+type File struct {
+	Path    string
+	Content []byte
+}
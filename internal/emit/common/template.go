@@ -0,0 +1,56 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"text/template"
+)
+
+// LoadTemplate parses the embedded template tplPath from fsys. If overrideDir
+// contains a file with the same base name (e.g. "server.go.tpl"), it is parsed
+// into the same template set afterwards:
+//
+//   - a file with a top-level body replaces the built-in template entirely;
+//   - a file with only {{define}} blocks redefines just those blocks
+//     (e.g. {{define "handlerBody"}}), keeping the rest of the built-in.
+func LoadTemplate(fsys fs.FS, tplPath, overrideDir string, fm template.FuncMap) (*template.Template, error) {
+	name := path.Base(tplPath)
+
+	tplText, err := fs.ReadFile(fsys, tplPath)
+	if err != nil {
+		return nil, fmt.Errorf("read template %s: %w", tplPath, err)
+	}
+	tpl, err := template.New(name).Funcs(fm).Parse(string(tplText))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", tplPath, err)
+	}
+
+	if overrideDir == "" {
+		return tpl, nil
+	}
+	overridePath := filepath.Join(overrideDir, name)
+	override, err := os.ReadFile(overridePath)
+	if os.IsNotExist(err) {
+		return tpl, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read template override: %w", err)
+	}
+	if _, err := tpl.Parse(string(override)); err != nil {
+		return nil, fmt.Errorf("parse template override %s: %w", overridePath, err)
+	}
+	return tpl, nil
+}
+
+// ExecTemplate runs tpl against data.
+func ExecTemplate(tpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("exec template %s: %w", tpl.Name(), err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,224 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Tx applies a set of file writes and deletions as a unit: either every change
+// lands on disk or, on failure, the previous contents are restored.
+//
+// Commit first stages all new contents as temp files next to their targets
+// (nothing visible changes if that fails), then swaps them in while keeping
+// backups of replaced and deleted files, and finally drops the backups.
+type Tx struct {
+	writes  []txWrite
+	deletes []txDelete
+}
+
+type txWrite struct {
+	path string
+	data []byte
+}
+
+type txDelete struct {
+	path string
+	root string // empty parents up to root are removed after commit
+}
+
+func (tx *Tx) Write(p string, data []byte) {
+	tx.writes = append(tx.writes, txWrite{path: p, data: data})
+}
+
+// Delete removes p on commit, then any directories between p and root left empty.
+func (tx *Tx) Delete(p, root string) {
+	tx.deletes = append(tx.deletes, txDelete{path: p, root: root})
+}
+
+type undoKind int
+
+const (
+	undoCreated  undoKind = iota // remove path
+	undoReplaced                 // rename backup -> path
+)
+
+type undoStep struct {
+	kind   undoKind
+	path   string
+	backup string
+}
+
+// Commit applies the transaction and returns the paths whose content changed.
+// Files whose content is already up to date are left untouched.
+func (tx *Tx) Commit() (written []string, err error) {
+	var (
+		staged  = map[string]string{} // path -> temp file
+		created []string              // directories created while staging
+		undo    []undoStep
+		backups []string
+	)
+
+	cleanupStaged := func() {
+		for _, tmp := range staged {
+			_ = os.Remove(tmp)
+		}
+	}
+	rollback := func() error {
+		var errs []error
+		for i := len(undo) - 1; i >= 0; i-- {
+			u := undo[i]
+			switch u.kind {
+			case undoCreated:
+				if err := os.Remove(u.path); err != nil && !os.IsNotExist(err) {
+					errs = append(errs, err)
+				}
+			case undoReplaced:
+				if err := os.Rename(u.backup, u.path); err != nil {
+					errs = append(errs, fmt.Errorf("restore %s from %s: %w", u.path, u.backup, err))
+				}
+			}
+		}
+		cleanupStaged()
+		for i := len(created) - 1; i >= 0; i-- {
+			_ = os.Remove(created[i])
+		}
+		return errors.Join(errs...)
+	}
+	fail := func(err error) ([]string, error) {
+		if rbErr := rollback(); rbErr != nil {
+			return nil, fmt.Errorf("%w (rollback incomplete: %v)", err, rbErr)
+		}
+		return nil, fmt.Errorf("%w (no files were changed)", err)
+	}
+
+	// 1. stage
+	var changed []string
+	for _, w := range tx.writes {
+		existing, readErr := os.ReadFile(w.path)
+		if readErr == nil && bytes.Equal(existing, w.data) {
+			continue
+		}
+		if readErr != nil && !os.IsNotExist(readErr) {
+			return fail(fmt.Errorf("read existing: %w", readErr))
+		}
+
+		dirs, err := mkdirAll(filepath.Dir(w.path))
+		created = append(created, dirs...)
+		if err != nil {
+			return fail(fmt.Errorf("mkdir: %w", err))
+		}
+		tmp, err := writeTemp(w.path, w.data)
+		if err != nil {
+			return fail(fmt.Errorf("write tmp: %w", err))
+		}
+		staged[w.path] = tmp
+		changed = append(changed, w.path)
+	}
+
+	// 2. swap
+	for _, p := range changed {
+		backup, err := backupFile(p)
+		if err != nil {
+			return fail(err)
+		}
+		if backup != "" {
+			backups = append(backups, backup)
+			undo = append(undo, undoStep{kind: undoReplaced, path: p, backup: backup})
+		}
+		if err := os.Rename(staged[p], p); err != nil {
+			return fail(fmt.Errorf("rename tmp: %w", err))
+		}
+		delete(staged, p)
+		if backup == "" {
+			undo = append(undo, undoStep{kind: undoCreated, path: p})
+		}
+	}
+	for _, d := range tx.deletes {
+		backup, err := backupFile(d.path)
+		if err != nil {
+			return fail(err)
+		}
+		if backup != "" {
+			backups = append(backups, backup)
+			undo = append(undo, undoStep{kind: undoReplaced, path: d.path, backup: backup})
+		}
+	}
+
+	// 3. committed: drop backups
+	for _, b := range backups {
+		_ = os.Remove(b)
+	}
+	for _, d := range tx.deletes {
+		removeEmptyParents(d.path, d.root)
+	}
+	return changed, nil
+}
+
+// backupFile moves p aside and returns the backup path ("" if p doesn't exist).
+func backupFile(p string) (string, error) {
+	if _, err := os.Lstat(p); os.IsNotExist(err) {
+		return "", nil
+	}
+	f, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".bak-*")
+	if err != nil {
+		return "", fmt.Errorf("backup: %w", err)
+	}
+	backup := f.Name()
+	_ = f.Close()
+	if err := os.Rename(p, backup); err != nil {
+		_ = os.Remove(backup)
+		return "", fmt.Errorf("backup %s: %w", p, err)
+	}
+	return backup, nil
+}
+
+func writeTemp(p string, data []byte) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// mkdirAll is os.MkdirAll that reports the directories it created, outermost first.
+func mkdirAll(dir string) ([]string, error) {
+	var missing []string
+	for d := filepath.Clean(dir); ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = append([]string{d}, missing...)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	return missing, os.MkdirAll(dir, 0o755)
+}
+
+// removeEmptyParents removes now-empty directories between p and root.
+func removeEmptyParents(p, root string) {
+	root = filepath.Clean(root)
+	for dir := filepath.Dir(p); dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
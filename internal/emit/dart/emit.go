@@ -0,0 +1,43 @@
+// Package dart emits a null-safe Dart client for Flutter: model classes with
+// fromJson/toJson (models.dart), a typed API class per tag (api_client.dart)
+// and the transport on an injectable package:http client (transport.dart).
+// No build_runner step is needed.
+package dart
+
+import (
+	"embed"
+	"text/template"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/*.dart.tpl
+var tplFS embed.FS
+
+func Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	data, err := BuildTemplateData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{"dartstr": dartString}
+
+	var files []common.File
+	for _, o := range []struct{ tpl, out string }{
+		{"templates/models.dart.tpl", "models.dart"},
+		{"templates/transport.dart.tpl", "transport.dart"},
+		{"templates/api_client.dart.tpl", "api_client.dart"},
+	} {
+		tpl, err := common.LoadTemplate(tplFS, o.tpl, opt.TemplatesDir, funcs)
+		if err != nil {
+			return nil, err
+		}
+		out, err := common.ExecTemplate(tpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, common.File{Path: o.out, Content: out})
+	}
+	return files, nil
+}
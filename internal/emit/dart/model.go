@@ -0,0 +1,574 @@
+package dart
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+type EmitOptions struct {
+	TemplatesDir string // optional user template overrides
+}
+
+type TemplateData struct {
+	BaseURL string
+	Types   []DartType
+	Tags    []DartTag
+}
+
+type DartType struct {
+	Name string
+	Kind string // "class" | "enum" | "alias"
+
+	// class
+	Fields []DartField
+
+	// enum
+	Values  []DartEnumValue
+	Unknown string // value decoded for wire values this client doesn't know
+
+	// alias
+	Alias string
+}
+
+type DartField struct {
+	Name     string
+	JSONName string
+	Type     string // Dart type, nullable when Nullable or not Required
+	Required bool   // required constructor parameter; always serialized
+	Decode   string // expression reading the field from `json`
+	Encode   string // expression producing the JSON value
+}
+
+type DartEnumValue struct {
+	Name  string
+	Value string
+}
+
+type DartTag struct {
+	Name   string // class prefix, e.g. "User" -> UserApi
+	Field  string // field on ApiClient, e.g. "user"
+	Routes []DartRoute
+}
+
+type DartRoute struct {
+	Name       string // operationId
+	Method     string // GET/POST
+	MethodName string
+	Params     string // parameter list
+	ReturnType string
+	PathExpr   string // Dart string literal building the escaped path
+	Query      []DartQuery
+	Body       string // JSON body expression, "" without body
+	Decode     string // expression decoding `json`
+}
+
+type DartQuery struct {
+	JSONName string
+	Expr     string // String? expression
+}
+
+var keywords = map[string]bool{
+	"assert": true, "await": true, "break": true, "case": true, "catch": true, "class": true,
+	"const": true, "continue": true, "default": true, "do": true, "else": true, "enum": true,
+	"extends": true, "false": true, "final": true, "finally": true, "for": true, "if": true,
+	"in": true, "is": true, "new": true, "null": true, "rethrow": true, "return": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true, "try": true,
+	"var": true, "void": true, "while": true, "with": true, "yield": true,
+}
+
+// objectMembers are inherited from Object and cannot be redeclared as fields
+// or methods.
+var objectMembers = []string{"hashCode", "runtimeType", "toString", "noSuchMethod"}
+
+func BuildTemplateData(spec *ir.Spec) (*TemplateData, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil IR spec")
+	}
+	m := &mapper{types: spec.Types}
+
+	data := &TemplateData{BaseURL: spec.Meta.BaseURL}
+	types, err := m.buildTypes()
+	if err != nil {
+		return nil, err
+	}
+	data.Types = types
+	tags, err := m.buildTags(spec.Routes)
+	if err != nil {
+		return nil, err
+	}
+	data.Tags = tags
+	return data, nil
+}
+
+type mapper struct {
+	types map[string]ir.TypeDecl
+}
+
+func (m *mapper) buildTypes() ([]DartType, error) {
+	names := make([]string, 0, len(m.types))
+	for n := range m.types {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	out := make([]DartType, 0, len(names))
+	for _, n := range names {
+		td := m.types[n]
+		name := server.GoPublicIdent(td.Name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid type name: %q", td.Name)
+		}
+
+		switch td.Type.Kind {
+		case ir.KindObject:
+			dt := DartType{Name: name, Kind: "class"}
+			used := usedSet(append(objectMembers, "fromJson", "toJson")...)
+			for _, f := range td.Type.Fields {
+				field := uniqueIdent(camelIdent(f.Name, "field"), used)
+				typ := m.render(f.Type)
+				if !f.Required {
+					typ = optional(typ)
+				}
+				dt.Fields = append(dt.Fields, DartField{
+					Name:     field,
+					JSONName: f.Name,
+					Type:     typ,
+					Required: f.Required,
+					Decode:   m.decode(f.Type, "json["+dartString(f.Name)+"]", !f.Required, 0, nil),
+					Encode:   m.encode(f.Type, field, !f.Required, 0, nil),
+				})
+			}
+			out = append(out, dt)
+		case ir.KindEnum:
+			dt := DartType{Name: name, Kind: "enum"}
+			used := usedSet(append(objectMembers, "values", "index", "name", "value", "fromJson", "toJson")...)
+			for _, v := range td.Type.Enum {
+				dt.Values = append(dt.Values, DartEnumValue{
+					Name:  uniqueIdent(camelIdent(v, "value"), used),
+					Value: v,
+				})
+			}
+			dt.Unknown = uniqueIdent("unknown", used)
+			out = append(out, dt)
+		case ir.KindScalar, ir.KindArray:
+			out = append(out, DartType{
+				Name:  name,
+				Kind:  "alias",
+				Alias: m.renderInline(td.Type),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported type kind: %s", td.Type.Kind)
+		}
+	}
+	return out, nil
+}
+
+func (m *mapper) buildTags(routes []ir.Route) ([]DartTag, error) {
+	groups := server.GroupRoutes(routes)
+	out := make([]DartTag, 0, len(groups))
+	fields := usedSet(append(objectMembers, "close")...)
+	for _, g := range groups {
+		dt := DartTag{Name: g.Name, Field: uniqueIdent(camelIdent(g.Name, "tag"), fields)}
+		methods := usedSet(objectMembers...)
+		for _, r := range g.Routes {
+			dr, err := m.toRoute(r, methods)
+			if err != nil {
+				return nil, fmt.Errorf("route %s.%s: %w", g.Name, r.Name, err)
+			}
+			dt.Routes = append(dt.Routes, dr)
+		}
+		out = append(out, dt)
+	}
+	return out, nil
+}
+
+func (m *mapper) toRoute(r ir.Route, methods map[string]bool) (DartRoute, error) {
+	if r.Success.Type == nil {
+		return DartRoute{}, fmt.Errorf("missing 200 response schema")
+	}
+	if r.Method != "GET" && r.Method != "POST" {
+		return DartRoute{}, fmt.Errorf("unsupported method %q", r.Method)
+	}
+	name := camelIdent(r.Name, "")
+	if name == "" {
+		return DartRoute{}, fmt.Errorf("invalid operationId: %q", r.Name)
+	}
+
+	used := usedSet("body")
+	var params, named []string
+
+	pathArgs := map[string]string{}
+	for _, p := range r.PathParams {
+		arg := uniqueIdent(camelIdent(p.Name, "param"), used)
+		pathArgs[p.Name] = m.format(p.Type, arg, false)
+		params = append(params, fmt.Sprintf("%s %s", m.render(p.Type), arg))
+	}
+	pathExpr, err := buildPathExpr(r.Path, pathArgs)
+	if err != nil {
+		return DartRoute{}, err
+	}
+
+	var body string
+	if r.Method == "POST" && r.RequestBody != nil {
+		typ := m.render(r.RequestBody.Type)
+		if r.RequestBody.Required {
+			params = append(params, typ+" body")
+			body = m.encode(r.RequestBody.Type, "body", false, 0, nil)
+		} else {
+			named = append(named, optional(typ)+" body")
+			body = m.encode(r.RequestBody.Type, "body", true, 0, nil)
+		}
+	}
+
+	var query []DartQuery
+	for _, p := range r.QueryParams {
+		arg := uniqueIdent(camelIdent(p.Name, "param"), used)
+		typ := m.render(p.Type)
+		if p.Required && !strings.HasSuffix(typ, "?") {
+			named = append(named, fmt.Sprintf("required %s %s", typ, arg))
+		} else {
+			typ = optional(typ)
+			named = append(named, fmt.Sprintf("%s %s", typ, arg))
+		}
+		query = append(query, DartQuery{JSONName: p.Name, Expr: m.format(p.Type, arg, strings.HasSuffix(typ, "?"))})
+	}
+	if len(named) > 0 {
+		params = append(params, "{"+strings.Join(named, ", ")+"}")
+	}
+
+	return DartRoute{
+		Name:       r.Name,
+		Method:     r.Method,
+		MethodName: uniqueIdent(name, methods),
+		Params:     strings.Join(params, ", "),
+		ReturnType: m.render(*r.Success.Type),
+		PathExpr:   pathExpr,
+		Query:      query,
+		Body:       body,
+		Decode:     m.decode(*r.Success.Type, "json", false, 0, nil),
+	}, nil
+}
+
+// buildPathExpr turns "/users/{id}" into `'/users/${pathEscape(id)}'`;
+// values maps parameter names to String expressions.
+func buildPathExpr(p string, values map[string]string) (string, error) {
+	var b strings.Builder
+	b.WriteByte('\'')
+	rest := p
+	for {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			break
+		}
+		j := strings.Index(rest[i:], "}")
+		if j < 0 {
+			break
+		}
+		b.WriteString(escapeString(rest[:i]))
+		name := rest[i+1 : i+j]
+		value, ok := values[name]
+		if !ok {
+			return "", fmt.Errorf("path parameter %q is not declared", name)
+		}
+		b.WriteString("${pathEscape(" + value + ")}")
+		rest = rest[i+j+1:]
+	}
+	b.WriteString(escapeString(rest))
+	b.WriteByte('\'')
+	return b.String(), nil
+}
+
+// ---- type rendering ----
+
+// render returns the Dart type of tr; inline objects are maps and untyped
+// values Object?.
+func (m *mapper) render(tr ir.TypeRef) string {
+	if tr.RefName != "" {
+		t := server.GoPublicIdent(tr.RefName)
+		if m.nullable(tr) {
+			return optional(t)
+		}
+		return t
+	}
+	if tr.Inline != nil {
+		return m.renderInline(*tr.Inline)
+	}
+	return "Object?"
+}
+
+func (m *mapper) renderInline(t ir.Type) string {
+	var s string
+	switch t.Kind {
+	case ir.KindScalar:
+		switch t.Scalar {
+		case "string":
+			s = "String"
+		case "number":
+			s = "double"
+		case "integer":
+			s = "int"
+		case "boolean":
+			s = "bool"
+		default:
+			return "Object?"
+		}
+	case ir.KindEnum:
+		// inline enums stay strings, as in go-server
+		s = "String"
+	case ir.KindArray:
+		elem := "Object?"
+		if t.Elem != nil {
+			elem = m.render(*t.Elem)
+		}
+		s = "List<" + elem + ">"
+	case ir.KindObject:
+		s = "Map<String, dynamic>"
+	default:
+		return "Object?"
+	}
+	if t.Nullable {
+		return optional(s)
+	}
+	return s
+}
+
+// nullable reports whether tr (following refs) admits null.
+func (m *mapper) nullable(tr ir.TypeRef) bool {
+	if tr.Inline != nil {
+		return tr.Inline.Nullable
+	}
+	if td, ok := m.types[tr.RefName]; ok {
+		return td.Type.Nullable
+	}
+	return false
+}
+
+// resolve follows aliases to the type that decides how values of tr are
+// converted. The returned name is set for classes and enums.
+func (m *mapper) resolve(tr ir.TypeRef, seen map[string]bool) (name string, t *ir.Type, next map[string]bool) {
+	if tr.RefName == "" {
+		return "", tr.Inline, seen
+	}
+	td, ok := m.types[tr.RefName]
+	if !ok || seen[tr.RefName] {
+		return "", nil, seen
+	}
+	if td.Type.Kind == ir.KindObject || td.Type.Kind == ir.KindEnum {
+		return server.GoPublicIdent(tr.RefName), &td.Type, seen
+	}
+	next = map[string]bool{tr.RefName: true}
+	for k := range seen {
+		next[k] = true
+	}
+	return m.resolve(ir.TypeRef{Inline: &td.Type}, next)
+}
+
+// decode returns an expression converting the decoded JSON value v to the
+// Dart type of tr (made nullable when optional is set).
+func (m *mapper) decode(tr ir.TypeRef, v string, optional bool, depth int, seen map[string]bool) string {
+	name, t, seen := m.resolve(tr, seen)
+	if t == nil {
+		return v
+	}
+	var expr string
+	switch {
+	case name != "" && t.Kind == ir.KindObject:
+		expr = name + ".fromJson(" + v + " as Map<String, dynamic>)"
+	case name != "":
+		expr = name + ".fromJson(" + v + " as String)"
+	case t.Kind == ir.KindArray:
+		e := fmt.Sprintf("e%d", depth)
+		elem := e
+		if t.Elem != nil {
+			elem = m.decode(*t.Elem, e, false, depth+1, seen)
+		}
+		expr = "(" + v + " as List<dynamic>).map((" + e + ") => " + elem + ").toList()"
+	case t.Kind == ir.KindObject:
+		expr = v + " as Map<String, dynamic>"
+	case t.Kind == ir.KindEnum:
+		expr = v + " as String"
+	case t.Kind == ir.KindScalar:
+		switch t.Scalar {
+		case "string":
+			expr = v + " as String"
+		case "integer":
+			expr = "(" + v + " as num).toInt()"
+		case "number":
+			expr = "(" + v + " as num).toDouble()"
+		case "boolean":
+			expr = v + " as bool"
+		default:
+			return v
+		}
+	default:
+		return v
+	}
+	if optional || t.Nullable {
+		return v + " == null ? null : " + expr
+	}
+	return expr
+}
+
+// encode returns an expression converting x, of the Dart type of tr (made
+// nullable when optional is set), to a JSON-encodable value.
+func (m *mapper) encode(tr ir.TypeRef, x string, optional bool, depth int, seen map[string]bool) string {
+	name, t, seen := m.resolve(tr, seen)
+	if t == nil {
+		return x
+	}
+	dot := "."
+	if optional || t.Nullable {
+		dot = "?."
+	}
+	switch {
+	case name != "":
+		return x + dot + "toJson()"
+	case t.Kind == ir.KindArray && t.Elem != nil:
+		e := fmt.Sprintf("e%d", depth)
+		elem := m.encode(*t.Elem, e, false, depth+1, seen)
+		if elem == e {
+			return x
+		}
+		return x + dot + "map((" + e + ") => " + elem + ").toList()"
+	}
+	return x
+}
+
+// format returns a String expression for the path or query value x; when
+// optional is set the result is null for a null x.
+func (m *mapper) format(tr ir.TypeRef, x string, optional bool) string {
+	name, t, _ := m.resolve(tr, nil)
+	dot := "."
+	if optional {
+		dot = "?."
+	}
+	switch {
+	case name != "" && t.Kind == ir.KindEnum:
+		return x + dot + "value"
+	case t != nil && t.Kind == ir.KindScalar && t.Scalar == "string" && !t.Nullable,
+		t != nil && t.Kind == ir.KindEnum && name == "" && !t.Nullable:
+		return x
+	}
+	return x + dot + "toString()"
+}
+
+func optional(typ string) string {
+	if strings.HasSuffix(typ, "?") {
+		return typ
+	}
+	return typ + "?"
+}
+
+// ---- identifiers ----
+
+// camelIdent converts a JSON or operation name to a lowerCamelCase Dart
+// identifier: "next-cursor" -> "nextCursor", "user_id" -> "userId". Names
+// starting with a digit get prefix; reserved words get a trailing "_".
+func camelIdent(s, prefix string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return prefix
+	}
+	var b strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+	}
+	out := b.String()
+	if out[0] >= '0' && out[0] <= '9' {
+		if prefix == "" {
+			return ""
+		}
+		out = prefix + out
+	}
+	if keywords[out] {
+		out += "_"
+	}
+	return out
+}
+
+// splitWords splits on non-alphanumerics and lower-to-upper case changes:
+// "userID" -> [user ID], "next-cursor" -> [next cursor].
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	for i, r := range runes {
+		if r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return words
+}
+
+func usedSet(names ...string) map[string]bool {
+	used := make(map[string]bool, len(names))
+	for _, n := range names {
+		used[n] = true
+	}
+	return used
+}
+
+// uniqueIdent returns name, or name with a numeric suffix if it is taken,
+// and marks the result as used.
+func uniqueIdent(name string, used map[string]bool) string {
+	out := name
+	for i := 2; used[out]; i++ {
+		out = fmt.Sprintf("%s%d", name, i)
+	}
+	used[out] = true
+	return out
+}
+
+// dartString quotes s as a single-quoted Dart string literal.
+func dartString(s string) string {
+	return "'" + escapeString(s) + "'"
+}
+
+func escapeString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\'', '\\', '$':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u{%x}`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
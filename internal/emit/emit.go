@@ -2,9 +2,10 @@ package emit
 
 import (
 	"fmt"
+	"path"
+	"path/filepath"
 
-	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
-	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/ts/wx"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
@@ -13,9 +14,30 @@ type Options struct {
 	Targets []string
 	Check   bool
 	Verbose bool
+
+	// TemplatesDir holds user overrides for built-in templates (optional).
+	TemplatesDir string
+
+	// TargetOptions holds per-target options keyed by target name
+	// (e.g. "go-server", "plugin:kotlin").
+	TargetOptions map[string]map[string]string
+
+	// TargetOutDirs overrides the output directory per target
+	// (default: TargetDir(OutDir, target)).
+	TargetOutDirs map[string]string
+
+	// Force overwrites and prunes files even if they were edited by hand
+	// since the last generation.
+	Force bool
 }
 
-func Dispatch(spec *ir.Spec, opt Options) ([]string, error) {
+// Report lists the files changed on disk by Dispatch.
+type Report struct {
+	Written []string
+	Deleted []string
+}
+
+func Dispatch(spec *ir.Spec, opt Options) (*Report, error) {
 	if spec == nil {
 		return nil, fmt.Errorf("nil IR spec")
 	}
@@ -23,44 +45,94 @@ func Dispatch(spec *ir.Spec, opt Options) ([]string, error) {
 		// default target
 		opt.Targets = []string{"raw-ir"}
 	}
-	var files []string
+	var rendered []renderedFile
+	var targetDirs []string
+	owner := map[string]string{} // output path -> target writing it
 	for _, t := range opt.Targets {
-		switch t {
-		case "raw-ir":
-			// TODO: Emit raw IR for debugging
-			fmt.Printf("%+v\n", spec)
-		case "ts-wx":
-			fs1, err := wx.EmitTypes(spec, wx.EmitOptions{OutDir: opt.OutDir, Check: opt.Check})
-			if err != nil {
-				return nil, err
-			}
-			files = append(files, fs1...)
+		e, ok := Lookup(t)
+		if !ok {
+			return nil, fmt.Errorf("unknown target: %s", t)
+		}
 
-			fs2, err := wx.EmitTransport(spec, wx.EmitOptions{OutDir: opt.OutDir, Check: opt.Check})
-			if err != nil {
-				return nil, err
-			}
-			files = append(files, fs2...)
+		if err := ValidateOptions(e, opt.TargetOptions[t]); err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
 
-			fs3, err := wx.EmitClient(spec, wx.EmitOptions{OutDir: opt.OutDir, Check: opt.Check})
+		dir := opt.TargetOutDirs[t]
+		if dir == "" {
+			dir = TargetDir(opt.OutDir, t)
+		}
+		targetDirs = append(targetDirs, dir)
+
+		fs, err := e.Emit(spec, EmitOptions{
+			Options:      opt.TargetOptions[t],
+			TemplatesDir: opt.TemplatesDir,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range fs {
+			outPath, err := resolvePath(dir, f.Path)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("%s: %w", t, err)
 			}
-			files = append(files, fs3...)
-		case "go-server":
-			fs, err := server.Emit(spec, server.EmitOptions{
-				OutDir:  opt.OutDir,
-				Check:   opt.Check,
-				Package: "server",
-			})
-			if err != nil {
-				return nil, err
+			// targets can share a directory through per-target out dirs
+			if prev, dup := owner[outPath]; dup {
+				return nil, fmt.Errorf("%s: %s is also written by %s; give the targets different output directories", t, outPath, prev)
 			}
-			files = append(files, fs...)
+			owner[outPath] = t
+			rendered = append(rendered, renderedFile{
+				dir:     filepath.Clean(dir),
+				rel:     path.Clean(f.Path),
+				path:    outPath,
+				content: f.Content,
+			})
+		}
+	}
 
-		default:
-			return nil, fmt.Errorf("unknown target: %s", t)
+	if opt.Check {
+		// report every drifted file instead of stopping at the first one
+		if err := check(rendered, targetDirs); err != nil {
+			return nil, err
 		}
+		return &Report{}, nil
+	}
+
+	for i := range rendered {
+		rendered[i].content = common.Prepare(rendered[i].path, rendered[i].content)
+	}
+	plan, err := planWrites(rendered, opt.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	// commit every target as one unit so outputs never mix spec revisions
+	var tx common.Tx
+	isManifest := map[string]bool{}
+	for _, f := range plan.files {
+		tx.Write(f.path, f.content)
+	}
+	for _, f := range plan.manifests {
+		tx.Write(f.path, f.content)
+		isManifest[f.path] = true
+	}
+	for _, f := range plan.deletes {
+		tx.Delete(f.path, f.dir)
+	}
+	written, err := tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("write output: %w", err)
+	}
+
+	report := &Report{}
+	for _, p := range written {
+		if !isManifest[p] {
+			report.Written = append(report.Written, p)
+		}
+	}
+	for _, f := range plan.deletes {
+		report.Deleted = append(report.Deleted, f.path)
 	}
-	return files, nil
+	return report, nil
 }
@@ -0,0 +1,126 @@
+package emit
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+// EmitOptions are passed to an Emitter for one target.
+type EmitOptions struct {
+	// Options holds target-specific options (e.g. "package" for go-server).
+	Options map[string]string
+
+	// TemplatesDir holds user overrides for built-in templates (optional).
+	TemplatesDir string
+}
+
+// Emitter renders one target. Returned file paths are relative to the target's
+// output directory; writing (and --check) is handled by Dispatch.
+type Emitter interface {
+	Name() string
+	Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error)
+}
+
+// OptionValidator is implemented by emitters that check their target options
+// up front, so config errors surface before anything is rendered.
+type OptionValidator interface {
+	ValidateOptions(opts map[string]string) error
+}
+
+// ValidateOptions checks opts against e if it implements OptionValidator.
+func ValidateOptions(e Emitter, opts map[string]string) error {
+	if v, ok := e.(OptionValidator); ok {
+		return v.ValidateOptions(opts)
+	}
+	return nil
+}
+
+// knownOptions rejects option keys outside known.
+func knownOptions(opts map[string]string, known ...string) error {
+	keys := make([]string, 0, len(opts))
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !slices.Contains(known, k) {
+			if len(known) == 0 {
+				return fmt.Errorf("unknown option %q (target takes no options)", k)
+			}
+			return fmt.Errorf("unknown option %q (known: %s)", k, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+// TargetDir returns the default output directory of a target below outDir:
+// <outDir>/<target>, or <outDir>/<name> for "plugin:<name>".
+func TargetDir(outDir, target string) string {
+	name := strings.TrimPrefix(target, "plugin:")
+	return filepath.Join(outDir, name)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Emitter{}
+)
+
+// Register makes an emitter available as a target. It panics if the name is
+// empty, reserved for plugins or already registered.
+func Register(e Emitter) {
+	if e == nil {
+		panic("emit: Register emitter is nil")
+	}
+	name := e.Name()
+	if name == "" || strings.HasPrefix(name, "plugin:") {
+		panic(fmt.Sprintf("emit: invalid emitter name %q", name))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic("emit: Register called twice for emitter " + name)
+	}
+	registry[name] = e
+}
+
+// Lookup resolves a target name. "plugin:<name>" always resolves to an
+// external plugin emitter.
+func Lookup(name string) (Emitter, bool) {
+	if p, ok := strings.CutPrefix(name, "plugin:"); ok && p != "" {
+		return pluginEmitter{name: p}, true
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	e, ok := registry[name]
+	return e, ok
+}
+
+// Targets returns the registered target names in sorted order.
+func Targets() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]string, 0, len(registry))
+	for name := range registry {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// resolvePath keeps emitter output inside the target directory.
+func resolvePath(outDir, p string) (string, error) {
+	clean := path.Clean(p)
+	if p == "" || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || filepath.IsAbs(p) {
+		return "", fmt.Errorf("invalid file path %q (must be relative to the output directory)", p)
+	}
+	return filepath.Join(outDir, filepath.FromSlash(clean)), nil
+}
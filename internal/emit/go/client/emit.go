@@ -0,0 +1,73 @@
+package client
+
+import (
+	"embed"
+	"fmt"
+	"text/template"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/*.go.tpl
+var tplFS embed.FS
+
+func Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	data, err := BuildClientData(spec, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []common.File
+	if data.TypesImport == "" {
+		types, err := server.EmitTypes(data.ServerTemplateData, opt.TemplatesDir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, types)
+	}
+	for _, o := range []struct{ tpl, out string }{
+		{"templates/client_transport.go.tpl", "transport.go"},
+		{"templates/client.go.tpl", "client.gen.go"},
+	} {
+		tpl, err := common.LoadTemplate(tplFS, o.tpl, opt.TemplatesDir, funcMap(data.TypesAlias))
+		if err != nil {
+			return nil, err
+		}
+		out, err := common.ExecTemplate(tpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, common.File{Path: o.out, Content: out})
+	}
+	return files, nil
+}
+
+func funcMap(alias string) template.FuncMap {
+	return template.FuncMap{
+		"goMethodName": server.GoPublicIdent,
+		"qualify": func(typ string) string {
+			return server.Qualify(alias, typ)
+		},
+		// formatQuery renders a query value as the server's strconv parsing expects.
+		"formatQuery": func(f server.GoQueryField, expr string) string {
+			conv := func(typ string) string {
+				if f.BaseType == typ {
+					return expr
+				}
+				return typ + "(" + expr + ")"
+			}
+			switch f.ParseKind {
+			case "int64":
+				return fmt.Sprintf("strconv.FormatInt(%s, 10)", conv("int64"))
+			case "float64":
+				return fmt.Sprintf("strconv.FormatFloat(%s, 'f', -1, 64)", conv("float64"))
+			case "bool":
+				return fmt.Sprintf("strconv.FormatBool(%s)", conv("bool"))
+			default:
+				return conv("string")
+			}
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+type EmitOptions struct {
+	Package string // default "client"
+
+	// TypesImport is the import path of a go-server package whose
+	// types.gen.go (and RPCError) the client should use instead of emitting
+	// its own copy.
+	TypesImport string
+
+	TemplatesDir string // optional user template overrides
+}
+
+type ClientTemplateData struct {
+	*server.ServerTemplateData
+
+	// TypesImport/TypesAlias are set when models come from a go-server package.
+	TypesImport string
+	TypesAlias  string
+
+	Tags []ClientTag
+
+	UsesURL     bool // path or query parameters
+	UsesStrconv bool // non-string query parameters
+}
+
+type ClientTag struct {
+	Name   string // sanitized Go ident, as in <Tag>Service
+	Routes []ClientRoute
+}
+
+type ClientRoute struct {
+	server.GoRoute
+
+	// Signature is the <Tag>Service method signature with qualified types.
+	Signature string
+
+	// PathExpr is a Go expression building the escaped request path.
+	PathExpr string
+}
+
+var pathParamRe = regexp.MustCompile(`\{([^}]+)\}`)
+
+func BuildClientData(spec *ir.Spec, opt EmitOptions) (*ClientTemplateData, error) {
+	if opt.Package == "" {
+		opt.Package = "client"
+	}
+	sd, err := server.BuildServerData(spec, opt.Package)
+	if err != nil {
+		return nil, err
+	}
+	sd.Target = "go-client"
+
+	data := &ClientTemplateData{ServerTemplateData: sd, TypesImport: opt.TypesImport}
+	if opt.TypesImport != "" {
+		data.TypesAlias = typesAlias(opt.TypesImport, opt.Package)
+	}
+
+	for _, t := range sd.Tags {
+		ct := ClientTag{Name: t.Name}
+		for _, r := range t.Routes {
+			pathExpr, err := buildPathExpr(r)
+			if err != nil {
+				return nil, err
+			}
+			if r.HasPath || r.HasQuery {
+				data.UsesURL = true
+			}
+			for _, q := range r.QueryFields {
+				if q.ParseKind != "string" {
+					data.UsesStrconv = true
+				}
+			}
+			ct.Routes = append(ct.Routes, ClientRoute{
+				GoRoute:   r,
+				Signature: r.QualifiedServiceMethod(data.TypesAlias),
+				PathExpr:  pathExpr,
+			})
+		}
+		data.Tags = append(data.Tags, ct)
+	}
+	return data, nil
+}
+
+// buildPathExpr turns "/users/{id}" into `"/users/" + url.PathEscape(path.Id)`,
+// the inverse of pathParam on the server, which unescapes under every router.
+func buildPathExpr(r server.GoRoute) (string, error) {
+	fields := map[string]string{}
+	for _, f := range r.PathFields {
+		fields[f.JSONName] = f.Name
+	}
+
+	var parts []string
+	rest := r.Path
+	for _, m := range pathParamRe.FindAllStringSubmatchIndex(r.Path, -1) {
+		offset := len(r.Path) - len(rest)
+		if lit := rest[:m[0]-offset]; lit != "" {
+			parts = append(parts, fmt.Sprintf("%q", lit))
+		}
+		name := r.Path[m[2]:m[3]]
+		field, ok := fields[name]
+		if !ok {
+			return "", fmt.Errorf("%s: path parameter %q is not declared", r.Name, name)
+		}
+		parts = append(parts, "url.PathEscape(path."+field+")")
+		rest = r.Path[m[1]:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	return strings.Join(parts, " + "), nil
+}
+
+func typesAlias(importPath, pkg string) string {
+	alias := path.Base(importPath)
+	if server.GoPublicIdent(alias) == "" || !goIdentRe.MatchString(alias) || alias == pkg {
+		return "types"
+	}
+	return alias
+}
+
+var goIdentRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
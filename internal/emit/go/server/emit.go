@@ -1,10 +1,7 @@
 package server
 
 import (
-	"bytes"
 	"embed"
-	"fmt"
-	"path/filepath"
 	"text/template"
 
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
@@ -14,7 +11,7 @@ import (
 //go:embed templates/*.go.tpl
 var tplFS embed.FS
 
-func Emit(spec *ir.Spec, opt EmitOptions) ([]string, error) {
+func Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
 	if opt.Package == "" {
 		opt.Package = "server"
 	}
@@ -23,53 +20,48 @@ func Emit(spec *ir.Spec, opt EmitOptions) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	var files []string
-
-	if fs, err := emitOne("templates/types.go.tpl", filepath.Join(opt.OutDir, "go-server", "types.gen.go"), data, opt.Check, funcMap()); err != nil {
-		return nil, err
-	} else {
-		files = append(files, fs...)
+	if opt.Router != "" {
+		data.Router = opt.Router
 	}
-
-	if fs, err := emitOne("templates/transport.go.tpl", filepath.Join(opt.OutDir, "go-server", "transport.go"), data, opt.Check, funcMap()); err != nil {
-		return nil, err
-	} else {
-		files = append(files, fs...)
+	if opt.Errors != "" {
+		data.Errors = opt.Errors
 	}
 
-	if fs, err := emitOne("templates/server.go.tpl", filepath.Join(opt.OutDir, "go-server", "server.gen.go"), data, opt.Check, funcMap()); err != nil {
+	types, err := EmitTypes(data, opt.TemplatesDir)
+	if err != nil {
 		return nil, err
-	} else {
-		files = append(files, fs...)
+	}
+	files := []common.File{types}
+	for _, o := range []struct{ tpl, out string }{
+		{"templates/transport.go.tpl", "transport.go"},
+		{"templates/server.go.tpl", "server.gen.go"},
+	} {
+		f, err := emitOne(o.tpl, o.out, data, opt.TemplatesDir, funcMap())
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
 	}
 
 	return files, nil
 }
 
-func emitOne(tplPath, outPath string, data any, check bool, fm template.FuncMap) ([]string, error) {
-	tplText, err := tplFS.ReadFile(tplPath)
-	if err != nil {
-		return nil, fmt.Errorf("read template %s: %w", tplPath, err)
-	}
-	tpl, err := template.New(filepath.Base(tplPath)).Funcs(fm).Parse(string(tplText))
-	if err != nil {
-		return nil, fmt.Errorf("parse template %s: %w", tplPath, err)
-	}
-
-	var buf bytes.Buffer
-	if err := tpl.Execute(&buf, data); err != nil {
-		return nil, fmt.Errorf("exec template %s: %w", tplPath, err)
-	}
+// EmitTypes renders types.gen.go: the models plus the per-route path/query
+// structs. go-client emits the same file so both sides share one definition.
+func EmitTypes(data *ServerTemplateData, templatesDir string) (common.File, error) {
+	return emitOne("templates/types.go.tpl", "types.gen.go", data, templatesDir, funcMap())
+}
 
-	wrote, err := common.WriteFile(outPath, buf.Bytes(), common.WriteOptions{Check: check})
+func emitOne(tplPath, outPath string, data any, templatesDir string, fm template.FuncMap) (common.File, error) {
+	tpl, err := common.LoadTemplate(tplFS, tplPath, templatesDir, fm)
 	if err != nil {
-		return nil, err
+		return common.File{}, err
 	}
-	if wrote {
-		return []string{outPath}, nil
+	out, err := common.ExecTemplate(tpl, data)
+	if err != nil {
+		return common.File{}, err
 	}
-	return []string{}, nil
+	return common.File{Path: outPath, Content: out}, nil
 }
 
 func funcMap() template.FuncMap {
@@ -77,6 +69,7 @@ func funcMap() template.FuncMap {
 		"goMethodName": func(opID string) string {
 			return GoPublicIdent(opID)
 		},
+		"goLiteral": GoLiteral,
 		"enumConst": func(pkg, typeName, val string) string {
 			// const name: <TypeName><Val> (sanitized)
 			base := GoPublicIdent(typeName) + GoPublicIdent(val)
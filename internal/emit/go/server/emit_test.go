@@ -0,0 +1,83 @@
+package server
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+// TestEmitBuildsWithReservedOperationIDs compiles the stdlib-router output
+// for operationIds whose generated names once collided with OperationInfo,
+// OperationCall and OperationHandler.
+func TestEmitBuildsWithReservedOperationIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found")
+	}
+
+	str := ir.Type{Kind: ir.KindScalar, Scalar: "string"}
+	spec := &ir.Spec{
+		Types: map[string]ir.TypeDecl{
+			"Thing": {Name: "Thing", Type: ir.Type{Kind: ir.KindObject, Fields: []ir.Field{
+				{Name: "id", Required: true, Type: ir.TypeRef{Inline: &str}},
+			}}},
+		},
+	}
+	thing := &ir.TypeRef{RefName: "Thing"}
+	for _, name := range []string{"info", "call", "handler"} {
+		spec.Routes = append(spec.Routes, ir.Route{
+			Name:        name,
+			Tag:         "Ops",
+			Method:      "POST",
+			Path:        "/" + name,
+			RequestBody: &ir.Body{Required: true, Type: *thing},
+			Success:     ir.Success{Status: "200", Type: thing},
+		})
+	}
+
+	files, err := Emit(spec, EmitOptions{Router: "stdlib"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	gomod := "module example.com/gen\n\ngo 1.22\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.Path), f.Content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(gobin, "build", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+}
+
+func TestBuildServerDataOperationNameConflict(t *testing.T) {
+	spec := &ir.Spec{
+		Types: map[string]ir.TypeDecl{
+			"GetUserOperation": {Name: "GetUserOperation", Type: ir.Type{Kind: ir.KindObject}},
+		},
+		Routes: []ir.Route{{
+			Name:    "getUser",
+			Tag:     "Users",
+			Method:  "GET",
+			Path:    "/user",
+			Success: ir.Success{Status: "200", Type: &ir.TypeRef{RefName: "GetUserOperation"}},
+		}},
+	}
+	if _, err := BuildServerData(spec, ""); err == nil {
+		t.Fatal("expected a conflict error for schema GetUserOperation")
+	}
+}
@@ -2,25 +2,49 @@ package server
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
 type EmitOptions struct {
-	OutDir  string
-	Check   bool
-	Package string // default "server"
+	Package      string // default "server"
+	Router       string // "chi" (default), "stdlib", "gin" or "echo"
+	Errors       string // error wire format: "json" (default) or "problem"
+	TemplatesDir string // optional user template overrides
 }
 
+// Routers are the values of EmitOptions.Router.
+var Routers = []string{"chi", "stdlib", "gin", "echo"}
+
+// ErrorFormats are the values of EmitOptions.Errors. "problem" writes
+// RFC 7807 application/problem+json bodies instead of {"message", "data"}.
+var ErrorFormats = []string{"json", "problem"}
+
 type ServerTemplateData struct {
+	Target  string // emitter named in the generated-file header
 	Package string
 	BaseURL string
+	Router  string // see EmitOptions.Router
+	Errors  string // see EmitOptions.Errors
 
-	Types    []GoTypeDecl
-	Tags     []GoTag
-	HasQuery bool
+	Types      []GoTypeDecl
+	ErrorCodes []GoErrorCode // x-error-codes; ErrorCode is only declared when non-empty
+	Tags       []GoTag
+	HasQuery   bool
+
+	HasTimeout   bool // some route has a Timeout
+	HasBodyLimit bool // some route has a MaxBodyBytes
+}
+
+type GoErrorCode struct {
+	Const       string // e.g. "ErrorCodeUserNotFound"
+	Code        string // e.g. "USER_NOT_FOUND"
+	Status      int
+	Description string
 }
 
 type GoTag struct {
@@ -34,6 +58,13 @@ type GoRoute struct {
 	Path       string
 	MethodName string // Get/Post
 
+	// RoutePath is Path with each parameter renamed to its PathFields
+	// Param; MuxPattern is the http.ServeMux pattern for it and ColonPath
+	// the gin/echo form ("/users/:id").
+	RoutePath  string
+	MuxPattern string
+	ColonPath  string
+
 	TagName string // GoTag.Name
 
 	PathType  string
@@ -54,6 +85,12 @@ type GoRoute struct {
 	QueryFields []GoQueryField
 
 	HandlerName string // e.g. "handleGetUser"
+	InfoName    string // *OperationInfo variable, e.g. "GetUserOperation"
+
+	Timeout      string // x-timeout as a Go expression, e.g. "5*time.Second"; "" for none
+	MaxBodyBytes int64  // x-max-body-bytes, 0 for none
+
+	Extensions map[string]any // x-* fields of the operation
 }
 
 type GoQueryField struct {
@@ -64,6 +101,7 @@ type GoQueryField struct {
 	Required  bool
 	ParseKind string // string|int64|float64|bool
 	IsPointer bool
+	BaseType  string // Type without the pointer, e.g. "Status" for enums
 }
 
 type GoTypeDecl struct {
@@ -80,6 +118,8 @@ type GoField struct {
 	JSONName string
 	Type     string // Go type string
 	Tag      string // struct tag, including omitempty if needed
+
+	Param string // router wildcard name, for path fields only
 }
 
 func BuildServerData(spec *ir.Spec, pkg string) (*ServerTemplateData, error) {
@@ -91,8 +131,11 @@ func BuildServerData(spec *ir.Spec, pkg string) (*ServerTemplateData, error) {
 	}
 
 	data := &ServerTemplateData{
+		Target:  "go-server",
 		Package: pkg,
 		BaseURL: spec.Meta.BaseURL,
+		Router:  "chi",
+		Errors:  "json",
 	}
 
 	types, err := buildTypes(spec)
@@ -101,16 +144,70 @@ func BuildServerData(spec *ir.Spec, pkg string) (*ServerTemplateData, error) {
 	}
 	data.Types = types
 
+	codes, err := buildErrorCodes(spec, types)
+	if err != nil {
+		return nil, err
+	}
+	data.ErrorCodes = codes
+
 	tags, err := buildRoutes(spec)
 	if err != nil {
 		return nil, err
 	}
 	data.Tags = tags
 	data.HasQuery = hasQuery(tags)
+	typeNames := map[string]bool{}
+	for _, t := range types {
+		typeNames[t.Name] = true
+	}
+	for _, tag := range tags {
+		for _, route := range tag.Routes {
+			if typeNames[route.InfoName] {
+				return nil, fmt.Errorf("schema %q conflicts with the OperationInfo variable generated for %s", route.InfoName, route.Name)
+			}
+			data.HasTimeout = data.HasTimeout || route.Timeout != ""
+			data.HasBodyLimit = data.HasBodyLimit || route.MaxBodyBytes > 0
+		}
+	}
 
 	return data, nil
 }
 
+func buildErrorCodes(spec *ir.Spec, types []GoTypeDecl) ([]GoErrorCode, error) {
+	if len(spec.Meta.ErrorCodes) == 0 {
+		return nil, nil
+	}
+	for _, t := range types {
+		if t.Name == "ErrorCode" {
+			return nil, fmt.Errorf("schema %q conflicts with the ErrorCode type generated for x-error-codes", t.Name)
+		}
+	}
+	out := make([]GoErrorCode, 0, len(spec.Meta.ErrorCodes))
+	seen := map[string]string{}
+	for _, ec := range spec.Meta.ErrorCodes {
+		c := "ErrorCode" + errorCodeIdent(ec.Code)
+		if prev, dup := seen[c]; dup {
+			return nil, fmt.Errorf("error codes %q and %q both map to %s", prev, ec.Code, c)
+		}
+		seen[c] = ec.Code
+		out = append(out, GoErrorCode{Const: c, Code: ec.Code, Status: ec.Status, Description: strings.Join(strings.Fields(ec.Description), " ")})
+	}
+	return out, nil
+}
+
+// errorCodeIdent camel-cases an x-error-codes code, lowering all-caps words:
+// "USER_NOT_FOUND" -> "UserNotFound", "user.notFound" -> "UserNotFound".
+func errorCodeIdent(code string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(code, func(r rune) bool { return r == '_' || r == '.' || r == '-' }) {
+		if strings.ToUpper(part) == part {
+			part = strings.ToLower(part)
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
 func buildTypes(spec *ir.Spec) ([]GoTypeDecl, error) {
 	names := make([]string, 0, len(spec.Types))
 	for n := range spec.Types {
@@ -167,33 +264,47 @@ func buildTypes(spec *ir.Spec) ([]GoTypeDecl, error) {
 	return out, nil
 }
 
-func buildRoutes(spec *ir.Spec) ([]GoTag, error) {
-	byTag := map[string][]ir.Route{}
-	tagSet := map[string]bool{}
+// RouteGroup is the routes of one tag, as grouped for <Tag>Service.
+type RouteGroup struct {
+	Name   string // GoPublicIdent of the tag, "Default" when untagged
+	Routes []ir.Route
+}
 
-	for _, r := range spec.Routes {
+// GroupRoutes groups routes by tag, sorting tags and, within a tag,
+// operations by name. Every emitter with per-tag clients or services uses
+// it so that they all agree on the grouping.
+func GroupRoutes(routes []ir.Route) []RouteGroup {
+	byTag := map[string][]ir.Route{}
+	for _, r := range routes {
 		tag := GoPublicIdent(r.Tag)
 		if tag == "" {
 			tag = "Default"
 		}
 		byTag[tag] = append(byTag[tag], r)
-		tagSet[tag] = true
 	}
 
-	tags := make([]string, 0, len(tagSet))
-	for t := range tagSet {
+	tags := make([]string, 0, len(byTag))
+	for t := range byTag {
 		tags = append(tags, t)
 	}
 	sort.Strings(tags)
 
-	out := make([]GoTag, 0, len(tags))
+	out := make([]RouteGroup, 0, len(tags))
 	for _, t := range tags {
 		rs := byTag[t]
 		sort.Slice(rs, func(i, j int) bool { return rs[i].Name < rs[j].Name })
+		out = append(out, RouteGroup{Name: t, Routes: rs})
+	}
+	return out
+}
 
-		gt := GoTag{Name: t}
-		for _, r := range rs {
-			gr, err := toGoRoute(t, r, spec.Types)
+func buildRoutes(spec *ir.Spec) ([]GoTag, error) {
+	groups := GroupRoutes(spec.Routes)
+	out := make([]GoTag, 0, len(groups))
+	for _, g := range groups {
+		gt := GoTag{Name: g.Name}
+		for _, r := range g.Routes {
+			gr, err := toGoRoute(g.Name, r, spec.Types)
 			if err != nil {
 				return nil, err
 			}
@@ -256,9 +367,19 @@ func toGoRoute(tag string, r ir.Route, types map[string]ir.TypeDecl) (GoRoute, e
 				JSONName: p.Name,
 				Type:     "string",
 				Tag:      "",
+				Param:    wildcardName(p.Name, fn),
 			})
 		}
 	}
+	routePath, err := convertPath(r.Path, pathFields)
+	if err != nil {
+		return GoRoute{}, fmt.Errorf("%s: %w", r.Name, err)
+	}
+	muxPattern := r.Method + " " + routePath
+	if strings.HasSuffix(routePath, "/") {
+		// a trailing slash would make it a subtree pattern
+		muxPattern += "{$}"
+	}
 
 	var queryFields []GoQueryField
 	if hasQuery {
@@ -281,6 +402,7 @@ func toGoRoute(tag string, r ir.Route, types map[string]ir.TypeDecl) (GoRoute, e
 				Required:  p.Required,
 				ParseKind: parseKind,
 				IsPointer: strings.HasPrefix(goType, "*"),
+				BaseType:  strings.TrimPrefix(goType, "*"),
 			})
 		}
 	}
@@ -291,6 +413,10 @@ func toGoRoute(tag string, r ir.Route, types map[string]ir.TypeDecl) (GoRoute, e
 		Path:       r.Path,
 		MethodName: methodName,
 
+		RoutePath:  routePath,
+		MuxPattern: muxPattern,
+		ColonPath:  wildcardRe.ReplaceAllString(routePath, ":$1"),
+
 		TagName: tag,
 
 		PathType:  pathType,
@@ -309,9 +435,146 @@ func toGoRoute(tag string, r ir.Route, types map[string]ir.TypeDecl) (GoRoute, e
 		QueryFields: queryFields,
 
 		HandlerName: "handle" + op,
+		InfoName:    op + "Operation",
+
+		Timeout:      durationExpr(r.TimeoutMillis),
+		MaxBodyBytes: r.MaxBodyBytes,
+
+		Extensions: r.Extensions,
 	}, nil
 }
 
+// durationExpr renders ms as a time.Duration expression in the largest
+// whole unit: 5000 -> "5*time.Second".
+func durationExpr(ms int64) string {
+	switch {
+	case ms <= 0:
+		return ""
+	case ms%60000 == 0:
+		return fmt.Sprintf("%d*time.Minute", ms/60000)
+	case ms%1000 == 0:
+		return fmt.Sprintf("%d*time.Second", ms/1000)
+	default:
+		return fmt.Sprintf("%d*time.Millisecond", ms)
+	}
+}
+
+// ServiceMethod renders the route's <Tag>Service method signature, e.g.
+// "GetUser(ctx context.Context, path GetUserPath) (User, error)".
+func (r GoRoute) ServiceMethod() string {
+	return r.QualifiedServiceMethod("")
+}
+
+// QualifiedServiceMethod is ServiceMethod with generated types referenced
+// from package pkg (e.g. "api.User"); "" means the current package.
+func (r GoRoute) QualifiedServiceMethod(pkg string) string {
+	var b strings.Builder
+	b.WriteString(GoPublicIdent(r.Name))
+	b.WriteString("(ctx context.Context")
+	if r.HasPath {
+		b.WriteString(", path " + Qualify(pkg, r.PathType))
+	}
+	if r.HasQuery {
+		b.WriteString(", query *" + Qualify(pkg, r.QueryType))
+	}
+	if r.HasBody {
+		b.WriteString(", body " + Qualify(pkg, r.BodyType))
+	}
+	b.WriteString(") (" + Qualify(pkg, r.RespType) + ", error)")
+	return b.String()
+}
+
+// Qualify prefixes a generated type name with pkg. Types of the route model
+// are either generated identifiers or "any".
+func Qualify(pkg, typ string) string {
+	if pkg == "" || typ == "any" {
+		return typ
+	}
+	return pkg + "." + typ
+}
+
+// wildcardName is the router parameter name for a path parameter.
+// http.ServeMux only accepts Go identifiers, so other names fall back to
+// the path struct field name, which is unique within the route.
+func wildcardName(name, field string) string {
+	if goIdentRe.MatchString(name) {
+		return name
+	}
+	return field
+}
+
+var (
+	goIdentRe  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	wildcardRe = regexp.MustCompile(`\{([^{}/]+)\}`)
+)
+
+// convertPath renames the parameters of an OpenAPI path template to their
+// wildcard names: "/items/{item-id}" -> "/items/{ItemId}". chi and
+// http.ServeMux both match "{name}" against one path segment.
+func convertPath(p string, fields []GoField) (string, error) {
+	params := map[string]string{}
+	for _, f := range fields {
+		params[f.JSONName] = f.Param
+	}
+	var b strings.Builder
+	rest := p
+	for {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			break
+		}
+		j := strings.Index(rest[i:], "}")
+		if j < 0 {
+			return "", fmt.Errorf("unterminated path parameter in %q", p)
+		}
+		name := rest[i+1 : i+j]
+		param, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("path parameter %q is not declared", name)
+		}
+		b.WriteString(rest[:i] + "{" + param + "}")
+		rest = rest[i+j+1:]
+	}
+	b.WriteString(rest)
+	return b.String(), nil
+}
+
+// GoLiteral renders a decoded JSON value (as found in ir.Route.Extensions)
+// as a Go expression of the same dynamic type json.Unmarshal would produce.
+func GoLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return "float64(" + strconv.FormatFloat(v, 'g', -1, 64) + ")"
+	case int, int64, uint64:
+		return fmt.Sprintf("float64(%d)", v)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, e := range v {
+			parts = append(parts, GoLiteral(e))
+		}
+		return "[]any{" + strings.Join(parts, ", ") + "}"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			parts = append(parts, strconv.Quote(k)+": "+GoLiteral(v[k]))
+		}
+		return "map[string]any{" + strings.Join(parts, ", ") + "}"
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
+
 func goTypeFromTypeRef(tr ir.TypeRef, fallback string) string {
 	if tr.RefName != "" {
 		return GoPublicIdent(tr.RefName)
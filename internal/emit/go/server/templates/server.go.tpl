@@ -4,93 +4,273 @@ package {{ .Package }}
 
 import (
 	"context"
+	{{- if .HasBodyLimit }}
+	"errors"
+	{{- end }}
 	"net/http"
+	{{- if eq .Router "chi" "gin" "echo" }}
+	"net/url"
+	{{- end }}
 	{{- if .HasQuery }}
 	"strconv"
 	{{- end }}
+	{{- if .HasTimeout }}
+	"time"
+	{{- end }}
+	{{- if eq .Router "gin" }}
+
+	"github.com/gin-gonic/gin"
+	{{- else if eq .Router "echo" }}
+
+	"github.com/labstack/echo/v4"
+	{{- else if ne .Router "stdlib" }}
 
 	"github.com/go-chi/chi/v5"
+	{{- end }}
 )
 
-{{- /* Path structs + inline Body/Resp only when needed */}}
+{{- /* Service interfaces per Tag */}}
 
 {{- range .Tags }}
-{{- range .Routes }}
 
-{{- if .HasPath }}
-type {{ .PathType }} struct {
-{{- range .PathFields }}
-	{{ .Name }} {{ .Type }}
+type {{ .Name }}Service interface {
+{{- range .Routes }}
+	{{ .ServiceMethod }}
 {{- end }}
 }
+
 {{- end }}
 
-{{- if .HasQuery }}
-type {{ .QueryType }} struct {
-{{- range .QueryFields }}
-	{{ .Name }} {{ .Type }} {{ .Tag }}
+type Services struct {
+{{- range .Tags }}
+	{{ .Name }} {{ .Name }}Service
 {{- end }}
 }
-{{- end }}
 
-{{- if .HasBody }}
-{{- if .BodyInline }}
-type {{ .BodyType }} = any
-{{- end }}
-{{- end }}
+// OperationInfo describes one operation of the spec.
+type OperationInfo struct {
+	OperationID string
+	Tag         string // the <Tag>Service the operation belongs to
+	Method      string
+	Path        string
+	Extensions  map[string]any // x-* fields of the operation, nil if none
+}
 
-{{- if .RespInline }}
-type {{ .RespType }} = any
+var (
+{{- range .Tags }}
+{{- range .Routes }}
+	{{ .InfoName }} = &OperationInfo{
+		OperationID: {{ printf "%q" .Name }},
+		Tag:         {{ printf "%q" .TagName }},
+		Method:      {{ printf "%q" .Method }},
+		Path:        {{ printf "%q" .Path }},
+		{{- if .Extensions }}
+		Extensions:  {{ goLiteral .Extensions }},
+		{{- end }}
+	}
 {{- end }}
+{{- end }}
+)
 
+// Operations lists every operation, in registration order.
+var Operations = []*OperationInfo{
+{{- range .Tags }}
+{{- range .Routes }}
+	{{ .InfoName }},
 {{- end }}
 {{- end }}
+}
 
-{{- /* Service interfaces per Tag */}}
+// OperationCall is a decoded request about to reach its service method.
+// Path, Query and Body point at the decoded values (nil when the operation
+// has none), so interceptors can inspect or adjust them.
+type OperationCall struct {
+	Operation *OperationInfo
+	Request   *http.Request
+	Path      any // *<Op>Path
+	Query     any // *<Op>Query
+	Body      any // pointer to the request body
+}
 
-{{- range .Tags }}
+// OperationHandler calls the service method; the result is the response
+// body.
+type OperationHandler func(ctx context.Context, call *OperationCall) (any, error)
 
-type {{ .Name }}Service interface {
-{{- range .Routes }}
-	{{ goMethodName .Name }}(ctx context.Context{{ if .HasPath }}, path {{ .PathType }}{{ end }}{{ if .HasQuery }}, query *{{ .QueryType }}{{ end }}{{ if .HasBody }}, body {{ .BodyType }}{{ end }}) ({{ .RespType }}, error)
-{{- end }}
+// Interceptor wraps every service call. It may return early with an error
+// (e.g. *RPCError for a failed auth check), call next with a derived
+// context, or replace the result.
+type Interceptor func(ctx context.Context, call *OperationCall, next OperationHandler) (any, error)
+
+// RouteOption configures RegisterRoutes.
+type RouteOption func(*routeConfig)
+
+type routeConfig struct {
+	interceptors []Interceptor
 }
 
-{{- end }}
+// WithInterceptors adds interceptors; the first one is outermost.
+func WithInterceptors(in ...Interceptor) RouteOption {
+	return func(c *routeConfig) {
+		c.interceptors = append(c.interceptors, in...)
+	}
+}
 
-type Services struct {
-{{- range .Tags }}
-	{{ .Name }} {{ .Name }}Service
-{{- end }}
+// chain folds interceptors into one, nil if there are none.
+func chain(in []Interceptor) Interceptor {
+	if len(in) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, call *OperationCall, next OperationHandler) (any, error) {
+		for i := len(in) - 1; i >= 0; i-- {
+			ic, inner := in[i], next
+			next = func(ctx context.Context, call *OperationCall) (any, error) {
+				return ic(ctx, call, inner)
+			}
+		}
+		return next(ctx, call)
+	}
+}
+
+func invoke(ctx context.Context, call *OperationCall, ic Interceptor, h OperationHandler) (any, error) {
+	if ic == nil {
+		return h(ctx, call)
+	}
+	return ic(ctx, call, h)
 }
 
-func RegisterRoutes(r chi.Router, svc Services) {
+{{- /* Router adapter: everything framework-specific is in this section;
+       handlers below are plain http.HandlerFuncs shared by every router. */}}
+
+{{- if eq .Router "stdlib" }}
+
+func RegisterRoutes(mux *http.ServeMux, svc Services, opts ...RouteOption) {
+{{- else if eq .Router "gin" }}
+
+// RegisterRoutes adds every operation to r. For path parameters containing
+// escaped slashes (a%2Fb) to match and arrive decoded as under the other
+// routers, configure the engine with UseRawPath = true and
+// UnescapePathValues = false: ginHandler does the unescaping, and gin's own
+// would decode values twice (and turn "+" into a space).
+func RegisterRoutes(r gin.IRouter, svc Services, opts ...RouteOption) {
+{{- else if eq .Router "echo" }}
+
+func RegisterRoutes(g *echo.Group, svc Services, opts ...RouteOption) {
+{{- else }}
+
+func RegisterRoutes(r chi.Router, svc Services, opts ...RouteOption) {
+{{- end }}
 {{- range .Tags }}
 	if svc.{{ .Name }} == nil {
 		panic("Services.{{ .Name }} is nil")
 	}
 {{- end }}
+	var cfg routeConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+	ic := chain(cfg.interceptors)
 
 {{- range .Tags }}
 {{- range .Routes }}
-	r.{{ .MethodName }}({{ printf "%q" .Path }}, {{ .HandlerName }}(svc.{{ .TagName }}))
+{{- if eq $.Router "stdlib" }}
+	mux.HandleFunc({{ printf "%q" .MuxPattern }}, {{ .HandlerName }}(svc.{{ .TagName }}, ic))
+{{- else if eq $.Router "gin" }}
+	r.{{ .Method }}({{ printf "%q" .ColonPath }}, ginHandler({{ .HandlerName }}(svc.{{ .TagName }}, ic)))
+{{- else if eq $.Router "echo" }}
+	g.{{ .Method }}({{ printf "%q" .ColonPath }}, echoHandler({{ .HandlerName }}(svc.{{ .TagName }}, ic)))
+{{- else }}
+	r.{{ .MethodName }}({{ printf "%q" .RoutePath }}, {{ .HandlerName }}(svc.{{ .TagName }}, ic))
+{{- end }}
 {{- end }}
 {{- end }}
 }
+{{- if eq .Router "gin" }}
+
+// ginHandler runs h with the gin route parameters set as path values.
+// With UseRawPath gin matches the escaped path, so values are unescaped.
+func ginHandler(h http.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, p := range c.Params {
+			c.Request.SetPathValue(p.Key, unescapePathValue(c.Request, p.Value))
+		}
+		h(c.Writer, c.Request)
+	}
+}
+{{- else if eq .Router "echo" }}
+
+// echoHandler runs h with the echo route parameters set as path values.
+// Echo matches the escaped path when there is one, so values are unescaped
+// to match what ServeMux returns.
+func echoHandler(h http.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		r := c.Request()
+		values := c.ParamValues()
+		for i, name := range c.ParamNames() {
+			if i < len(values) {
+				r.SetPathValue(name, unescapePathValue(r, values[i]))
+			}
+		}
+		h(c.Response(), r)
+		return nil
+	}
+}
+{{- end }}
+
+{{- if .HasBodyLimit }}
+
+// tooLarge reports whether err came from an http.MaxBytesReader set up for
+// x-max-body-bytes.
+func tooLarge(err error) bool {
+	var mbe *http.MaxBytesError
+	return errors.As(err, &mbe)
+}
+{{- end }}
+
+// pathParam returns the named wildcard of the matched route, unescaped
+// ("a%2Fb" arrives as "a/b") under every router.
+func pathParam(r *http.Request, name string) string {
+{{- if eq .Router "stdlib" "gin" "echo" }}
+	return r.PathValue(name)
+{{- else }}
+	return unescapePathValue(r, chi.URLParam(r, name))
+{{- end }}
+}
+{{- if eq .Router "chi" "gin" "echo" }}
+
+// unescapePathValue decodes a value taken from r.URL.RawPath, which the
+// router matches instead of r.URL.Path when the request has escapes such as
+// %2F; without a RawPath the value is already decoded.
+func unescapePathValue(r *http.Request, v string) string {
+	if r.URL.RawPath == "" {
+		return v
+	}
+	if u, err := url.PathUnescape(v); err == nil {
+		return u
+	}
+	return v
+}
+{{- end }}
 
 {{- /* Handlers */}}
 
 {{- range .Tags }}
 {{- range .Routes }}
 
-func {{ .HandlerName }}(svc {{ .TagName }}Service) http.HandlerFunc {
+func {{ .HandlerName }}(svc {{ .TagName }}Service, ic Interceptor) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		{{- /* overridable block: define "handlerBody" in --templates/server.go.tpl (dot is a GoRoute) */}}
+		{{- block "handlerBody" . }}
 		ctx := r.Context()
+		{{- if .Timeout }}
+		ctx, cancel := context.WithTimeout(ctx, {{ .Timeout }})
+		defer cancel()
+		r = r.WithContext(ctx)
+		{{- end }}
 
 		{{- if .HasPath }}
 		var path {{ .PathType }}
 		{{- range .PathFields }}
-		path.{{ .Name }} = chi.URLParam(r, {{ printf "%q" .JSONName }})
+		path.{{ .Name }} = pathParam(r, {{ printf "%q" .Param }})
 		{{- end }}
 		{{- end }}
 
@@ -137,6 +317,13 @@ func {{ .HandlerName }}(svc {{ .TagName }}Service) http.HandlerFunc {
 			query.{{ .Name }} = parsed{{ .Name }}
 			{{- end }}
 		}
+		{{- else if ne .BaseType "string" }}
+		{{- if .IsPointer }}
+		parsed{{ .Name }} := {{ .BaseType }}(value{{ .Name }})
+		query.{{ .Name }} = &parsed{{ .Name }}
+		{{- else }}
+		query.{{ .Name }} = {{ .BaseType }}(value{{ .Name }})
+		{{- end }}
 		{{- else }}
 		{{- if .IsPointer }}
 		query.{{ .Name }} = &value{{ .Name }}
@@ -179,6 +366,13 @@ func {{ .HandlerName }}(svc {{ .TagName }}Service) http.HandlerFunc {
 				query.{{ .Name }} = parsed{{ .Name }}
 				{{- end }}
 			}
+			{{- else if ne .BaseType "string" }}
+			{{- if .IsPointer }}
+			parsed{{ .Name }} := {{ .BaseType }}(value{{ .Name }})
+			query.{{ .Name }} = &parsed{{ .Name }}
+			{{- else }}
+			query.{{ .Name }} = {{ .BaseType }}(value{{ .Name }})
+			{{- end }}
 			{{- else }}
 			{{- if .IsPointer }}
 			query.{{ .Name }} = &value{{ .Name }}
@@ -193,18 +387,46 @@ func {{ .HandlerName }}(svc {{ .TagName }}Service) http.HandlerFunc {
 
 		{{- if .HasBody }}
 		var body {{ .BodyType }}
+		{{- if .MaxBodyBytes }}
+		r.Body = http.MaxBytesReader(w, r.Body, {{ .MaxBodyBytes }})
+		{{- end }}
 		if err := ReadJSON(r, &body); err != nil {
+			{{- if .MaxBodyBytes }}
+			if tooLarge(err) {
+				WriteError(w, &RPCError{Status: http.StatusRequestEntityTooLarge, Message: "request body too large", Data: map[string]int64{"limit": {{ .MaxBodyBytes }}}})
+				return
+			}
+			{{- end }}
 			WriteError(w, &RPCError{Status: http.StatusBadRequest, Message: "invalid json", Data: err.Error()})
 			return
 		}
 		{{- end }}
 
-		resp, err := svc.{{ goMethodName .Name }}(ctx{{ if .HasPath }}, path{{ end }}{{ if .HasQuery }}, &query{{ end }}{{ if .HasBody }}, body{{ end }})
+		call := &OperationCall{Operation: {{ .InfoName }}, Request: r}
+		{{- if .HasPath }}
+		call.Path = &path
+		{{- end }}
+		{{- if .HasQuery }}
+		call.Query = &query
+		{{- end }}
+		{{- if .HasBody }}
+		call.Body = &body
+		{{- end }}
+		resp, err := invoke(ctx, call, ic, func(ctx context.Context, _ *OperationCall) (any, error) {
+			return svc.{{ goMethodName .Name }}(ctx{{ if .HasPath }}, path{{ end }}{{ if .HasQuery }}, &query{{ end }}{{ if .HasBody }}, body{{ end }})
+		})
+		{{- if .Timeout }}
+		if err == nil {
+			// the service returned after ctx ended: 504 past x-timeout, 499 if the client left
+			err = ctx.Err()
+		}
+		{{- end }}
 		if err != nil {
 			WriteError(w, err)
 			return
 		}
 		WriteJSON(w, http.StatusOK, resp)
+		{{- end }}
 	}
 }
 
@@ -3,17 +3,34 @@
 package {{ .Package }}
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 )
 
+// RPCError is the error services return to control the response: Status is
+// the HTTP status, Code an optional machine-readable code{{ if .ErrorCodes }} (see ErrorCode){{ end }}.
+// It may be wrapped; WriteError finds it with errors.As.
 type RPCError struct {
-	Status int         `json:"-"`
-	Message string     `json:"message"`
-	Data    any        `json:"data,omitempty"`
+	Status  int    `json:"-"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
 }
 
 func (e *RPCError) Error() string { return e.Message }
+{{- if .ErrorCodes }}
+
+// NewError returns an RPCError with code and the status declared for it.
+func NewError(code ErrorCode, message string) *RPCError {
+	return &RPCError{Status: code.Status(), Code: string(code), Message: message}
+}
+{{- end }}
+
+// StatusClientClosedRequest is sent when the request context was canceled,
+// which in practice means the client went away (nginx uses the same code).
+const StatusClientClosedRequest = 499
 
 func WriteJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -27,14 +44,62 @@ func ReadJSON(r *http.Request, v any) error {
 	return dec.Decode(v)
 }
 
+// WriteError writes err as an error response. An *RPCError anywhere in the
+// chain is written as is; context.Canceled becomes 499 and
+// context.DeadlineExceeded 504; anything else is a 500 "internal error".
 func WriteError(w http.ResponseWriter, err error) {
-	if re, ok := err.(*RPCError); ok {
-		WriteJSON(w, re.Status, re)
-		return
+	var re *RPCError
+	switch {
+	case errors.As(err, &re):
+	case errors.Is(err, context.DeadlineExceeded):
+		re = &RPCError{Status: http.StatusGatewayTimeout, Message: "deadline exceeded"}
+	case errors.Is(err, context.Canceled):
+		re = &RPCError{Status: StatusClientClosedRequest, Message: "request canceled"}
+	default:
+		re = &RPCError{Status: http.StatusInternalServerError, Message: "internal error"}
 	}
-	// default: 500
-	WriteJSON(w, http.StatusInternalServerError, &RPCError{
-		Status: http.StatusInternalServerError,
-		Message: "internal error",
+	writeRPCError(w, re)
+}
+{{- if eq .Errors "problem" }}
+
+// Problem is the RFC 7807 body written for every error. Code and Data are
+// extension members carrying RPCError.Code and RPCError.Data.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code,omitempty"`
+	Data   any    `json:"data,omitempty"`
+}
+
+func writeRPCError(w http.ResponseWriter, re *RPCError) {
+	status := re.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	title := http.StatusText(status)
+	if title == "" {
+		title = re.Message
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&Problem{
+		Type:   "about:blank",
+		Title:  title,
+		Status: status,
+		Detail: re.Message,
+		Code:   re.Code,
+		Data:   re.Data,
 	})
 }
+{{- else }}
+
+func writeRPCError(w http.ResponseWriter, re *RPCError) {
+	status := re.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	WriteJSON(w, status, re)
+}
+{{- end }}
@@ -0,0 +1,42 @@
+// Package kotlin emits a Kotlin client for Android: kotlinx.serialization
+// models (Models.kt), a suspend-function API class per tag (ApiClient.kt)
+// and the pluggable HttpTransport it is built on (Transport.kt).
+package kotlin
+
+import (
+	"embed"
+	"text/template"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/*.kt.tpl
+var tplFS embed.FS
+
+func Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	data, err := BuildTemplateData(spec, opt.Package)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{"ktstr": ktString}
+
+	var files []common.File
+	for _, o := range []struct{ tpl, out string }{
+		{"templates/Models.kt.tpl", "Models.kt"},
+		{"templates/Transport.kt.tpl", "Transport.kt"},
+		{"templates/ApiClient.kt.tpl", "ApiClient.kt"},
+	} {
+		tpl, err := common.LoadTemplate(tplFS, o.tpl, opt.TemplatesDir, funcs)
+		if err != nil {
+			return nil, err
+		}
+		out, err := common.ExecTemplate(tpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, common.File{Path: o.out, Content: out})
+	}
+	return files, nil
+}
@@ -0,0 +1,471 @@
+package kotlin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+type EmitOptions struct {
+	Package      string // default "api"
+	TemplatesDir string // optional user template overrides
+}
+
+type TemplateData struct {
+	Package  string
+	BaseURL  string
+	Types    []KtType
+	Tags     []KtTag
+	HasEnums bool // emit LenientEnumSerializer
+}
+
+type KtType struct {
+	Name string
+	Kind string // "class" | "enum" | "alias"
+
+	// class
+	Fields []KtField
+
+	// enum
+	Members []KtEnumMember
+	Unknown string // entry decoded for values this client doesn't know
+
+	// alias
+	Alias string
+}
+
+type KtField struct {
+	Name     string // property name, backquoted if needed
+	JSONName string
+	SerialAs bool   // JSON name differs from the property name
+	Type     string // Kotlin type, with "?" when optional or nullable
+	Default  bool   // "= null" (optional fields)
+}
+
+type KtEnumMember struct {
+	Name  string
+	Value string
+}
+
+type KtTag struct {
+	Name   string // class prefix, e.g. "User" -> UserApi
+	Prop   string // property on ApiClient, e.g. "user"
+	Routes []KtRoute
+}
+
+type KtRoute struct {
+	Name       string // operationId
+	Method     string // GET/POST
+	FunName    string
+	Params     string // parameter list
+	ReturnType string
+	PathExpr   string // Kotlin expression building the escaped path
+	Query      []KtQuery
+	Body       string // JSON text expression, "null" without body
+}
+
+type KtQuery struct {
+	JSONName string
+	Expr     string // String? expression
+}
+
+// reserved are names the generated API classes use themselves.
+var reserved = map[string]bool{"client": true, "body": true, "text": true}
+
+var keywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true,
+	"false": true, "for": true, "fun": true, "if": true, "in": true, "interface": true,
+	"is": true, "null": true, "object": true, "package": true, "return": true, "super": true,
+	"this": true, "throw": true, "true": true, "try": true, "typealias": true, "typeof": true,
+	"val": true, "var": true, "when": true, "while": true,
+}
+
+func BuildTemplateData(spec *ir.Spec, pkg string) (*TemplateData, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil IR spec")
+	}
+	if pkg == "" {
+		pkg = "api"
+	}
+	m := &mapper{types: spec.Types}
+
+	data := &TemplateData{Package: pkg, BaseURL: spec.Meta.BaseURL}
+	types, err := m.buildTypes()
+	if err != nil {
+		return nil, err
+	}
+	data.Types = types
+	for _, t := range types {
+		data.HasEnums = data.HasEnums || t.Kind == "enum"
+	}
+	if _, ok := spec.Types["LenientEnumSerializer"]; ok && data.HasEnums {
+		return nil, fmt.Errorf("schema %q conflicts with the serializer generated for enums", "LenientEnumSerializer")
+	}
+	tags, err := m.buildTags(spec.Routes)
+	if err != nil {
+		return nil, err
+	}
+	data.Tags = tags
+	return data, nil
+}
+
+type mapper struct {
+	types map[string]ir.TypeDecl
+}
+
+func (m *mapper) buildTypes() ([]KtType, error) {
+	names := make([]string, 0, len(m.types))
+	for n := range m.types {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	out := make([]KtType, 0, len(names))
+	for _, n := range names {
+		td := m.types[n]
+		name := server.GoPublicIdent(td.Name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid type name: %q", td.Name)
+		}
+
+		switch td.Type.Kind {
+		case ir.KindObject:
+			kt := KtType{Name: name, Kind: "class"}
+			used := map[string]bool{}
+			for _, f := range td.Type.Fields {
+				prop := uniqueIdent(camelIdent(f.Name, "field"), used)
+				typ := m.render(f.Type, true)
+				if !f.Required {
+					typ = nullable(typ)
+				}
+				kt.Fields = append(kt.Fields, KtField{
+					Name:     quoteKeyword(prop),
+					JSONName: f.Name,
+					SerialAs: prop != f.Name,
+					Type:     typ,
+					Default:  !f.Required,
+				})
+			}
+			out = append(out, kt)
+		case ir.KindEnum:
+			kt := KtType{Name: name, Kind: "enum"}
+			used := map[string]bool{}
+			for _, v := range td.Type.Enum {
+				kt.Members = append(kt.Members, KtEnumMember{
+					Name:  uniqueIdent(enumEntryName(v), used),
+					Value: v,
+				})
+			}
+			kt.Unknown = uniqueIdent("UNKNOWN", used)
+			out = append(out, kt)
+		case ir.KindScalar, ir.KindArray:
+			out = append(out, KtType{
+				Name:  name,
+				Kind:  "alias",
+				Alias: m.renderInline(td.Type, true),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported type kind: %s", td.Type.Kind)
+		}
+	}
+	return out, nil
+}
+
+func (m *mapper) buildTags(routes []ir.Route) ([]KtTag, error) {
+	groups := server.GroupRoutes(routes)
+	out := make([]KtTag, 0, len(groups))
+	props := map[string]bool{}
+	for _, g := range groups {
+		kt := KtTag{Name: g.Name, Prop: quoteKeyword(uniqueIdent(camelIdent(g.Name, "tag"), props))}
+		funs := map[string]bool{}
+		for _, r := range g.Routes {
+			kr, err := m.toRoute(r, funs)
+			if err != nil {
+				return nil, fmt.Errorf("route %s.%s: %w", g.Name, r.Name, err)
+			}
+			kt.Routes = append(kt.Routes, kr)
+		}
+		out = append(out, kt)
+	}
+	return out, nil
+}
+
+func (m *mapper) toRoute(r ir.Route, funs map[string]bool) (KtRoute, error) {
+	if r.Success.Type == nil {
+		return KtRoute{}, fmt.Errorf("missing 200 response schema")
+	}
+	if r.Method != "GET" && r.Method != "POST" {
+		return KtRoute{}, fmt.Errorf("unsupported method %q", r.Method)
+	}
+	name := camelIdent(r.Name, "")
+	if name == "" {
+		return KtRoute{}, fmt.Errorf("invalid operationId: %q", r.Name)
+	}
+
+	used := map[string]bool{}
+	for k := range reserved {
+		used[k] = true
+	}
+	var params []string
+
+	pathArgs := map[string]string{}
+	for _, p := range r.PathParams {
+		arg := quoteKeyword(uniqueIdent(camelIdent(p.Name, "param"), used))
+		pathArgs[p.Name] = arg
+		params = append(params, fmt.Sprintf("%s: %s", arg, m.render(p.Type, false)))
+	}
+	pathExpr, err := buildPathExpr(r.Path, pathArgs)
+	if err != nil {
+		return KtRoute{}, err
+	}
+
+	body := "null"
+	if r.Method == "POST" && r.RequestBody != nil {
+		typ := m.render(r.RequestBody.Type, false)
+		if r.RequestBody.Required {
+			params = append(params, "body: "+typ)
+			body = "client.json.encodeToString(body)"
+		} else {
+			params = append(params, fmt.Sprintf("body: %s = null", nullable(typ)))
+			body = "body?.let { client.json.encodeToString(it) }"
+		}
+	}
+
+	var query []KtQuery
+	for _, p := range r.QueryParams {
+		arg := quoteKeyword(uniqueIdent(camelIdent(p.Name, "param"), used))
+		typ := m.render(p.Type, false)
+		expr := arg + ".toString()"
+		if p.Required {
+			params = append(params, fmt.Sprintf("%s: %s", arg, typ))
+		} else {
+			params = append(params, fmt.Sprintf("%s: %s = null", arg, nullable(typ)))
+			expr = arg + "?.toString()"
+		}
+		query = append(query, KtQuery{JSONName: p.Name, Expr: expr})
+	}
+
+	return KtRoute{
+		Name:       r.Name,
+		Method:     r.Method,
+		FunName:    quoteKeyword(uniqueIdent(name, funs)),
+		Params:     strings.Join(params, ", "),
+		ReturnType: m.render(*r.Success.Type, false),
+		PathExpr:   pathExpr,
+		Query:      query,
+		Body:       body,
+	}, nil
+}
+
+// buildPathExpr turns "/users/{id}" into `"/users/" + pathEscape(id)`.
+func buildPathExpr(p string, args map[string]string) (string, error) {
+	var parts []string
+	rest := p
+	for {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			break
+		}
+		j := strings.Index(rest[i:], "}")
+		if j < 0 {
+			break
+		}
+		if i > 0 {
+			parts = append(parts, ktString(rest[:i]))
+		}
+		name := rest[i+1 : i+j]
+		arg, ok := args[name]
+		if !ok {
+			return "", fmt.Errorf("path parameter %q is not declared", name)
+		}
+		parts = append(parts, "pathEscape("+arg+")")
+		rest = rest[i+j+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, ktString(rest))
+	}
+	return strings.Join(parts, " + "), nil
+}
+
+// ---- type rendering ----
+
+// render returns the Kotlin type of tr. Inline objects become JsonObject in
+// models (field) and JsonElement elsewhere, like `any` in go-server.
+func (m *mapper) render(tr ir.TypeRef, field bool) string {
+	if tr.RefName != "" {
+		t := server.GoPublicIdent(tr.RefName)
+		if td, ok := m.types[tr.RefName]; ok && td.Type.Nullable {
+			return nullable(t)
+		}
+		return t
+	}
+	if tr.Inline != nil {
+		if tr.Inline.Kind == ir.KindObject && !field {
+			return "JsonElement"
+		}
+		return m.renderInline(*tr.Inline, field)
+	}
+	return "JsonElement"
+}
+
+func (m *mapper) renderInline(t ir.Type, field bool) string {
+	var s string
+	switch t.Kind {
+	case ir.KindScalar:
+		switch t.Scalar {
+		case "string":
+			s = "String"
+		case "number":
+			s = "Double"
+		case "integer":
+			s = "Long"
+		case "boolean":
+			s = "Boolean"
+		default:
+			s = "JsonElement"
+		}
+	case ir.KindEnum:
+		// inline enums stay strings, as in go-server
+		s = "String"
+	case ir.KindArray:
+		elem := "JsonElement"
+		if t.Elem != nil {
+			elem = m.render(*t.Elem, true)
+		}
+		s = "List<" + elem + ">"
+	case ir.KindObject:
+		s = "JsonObject"
+	default:
+		s = "JsonElement"
+	}
+	if t.Nullable {
+		return nullable(s)
+	}
+	return s
+}
+
+func nullable(typ string) string {
+	if strings.HasSuffix(typ, "?") {
+		return typ
+	}
+	return typ + "?"
+}
+
+// ---- identifiers ----
+
+// camelIdent converts a JSON or operation name to a lowerCamelCase Kotlin
+// identifier: "next-cursor" -> "nextCursor", "user_id" -> "userId". Names
+// starting with a digit get prefix.
+func camelIdent(s, prefix string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return prefix
+	}
+	var b strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+	}
+	out := b.String()
+	if out[0] >= '0' && out[0] <= '9' {
+		if prefix == "" {
+			return ""
+		}
+		out = prefix + strings.ToUpper(out[:1]) + out[1:]
+	}
+	return out
+}
+
+func enumEntryName(v string) string {
+	words := splitWords(v)
+	if len(words) == 0 {
+		return "EMPTY"
+	}
+	out := strings.ToUpper(strings.Join(words, "_"))
+	if out[0] >= '0' && out[0] <= '9' {
+		out = "V_" + out
+	}
+	return out
+}
+
+// splitWords splits on non-alphanumerics and lower-to-upper case changes:
+// "userID" -> [user ID], "next-cursor" -> [next cursor].
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	for i, r := range runes {
+		if r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return words
+}
+
+func quoteKeyword(name string) string {
+	if keywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// uniqueIdent returns name, or name with a numeric suffix if it is taken,
+// and marks the result as used.
+func uniqueIdent(name string, used map[string]bool) string {
+	out := name
+	for i := 2; used[out]; i++ {
+		out = fmt.Sprintf("%s%d", name, i)
+	}
+	used[out] = true
+	return out
+}
+
+// ktString quotes s as a Kotlin string literal ("$" starts a template).
+func ktString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\', '$':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
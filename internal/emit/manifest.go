@@ -0,0 +1,179 @@
+package emit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/version"
+)
+
+// ManifestName is the manifest file written into every target output dir.
+const ManifestName = ".openapi-rpc-codegen.json"
+
+const manifestVersion = 1
+
+// Manifest records the files generated into one output directory, so later
+// runs can prune files that are no longer produced and detect hand edits.
+type Manifest struct {
+	Version int    `json:"version"`
+	Tool    string `json:"tool"`
+
+	// Files maps slash-separated paths relative to the directory to
+	// "sha256:<hex>" of the content as written.
+	Files map[string]string `json:"files"`
+}
+
+func hashContent(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// readManifest returns nil when dir has no manifest.
+func readManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", filepath.Join(dir, ManifestName), err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("manifest %s: unsupported version %d", filepath.Join(dir, ManifestName), m.Version)
+	}
+	// Entries name files to overwrite and delete, so they get the same
+	// containment check as emitter output.
+	for rel := range m.Files {
+		if _, err := resolvePath(dir, rel); err != nil || rel == ManifestName {
+			return nil, fmt.Errorf("manifest %s: invalid entry %q (must be a path inside %s)", filepath.Join(dir, ManifestName), rel, dir)
+		}
+	}
+	return &m, nil
+}
+
+func newManifest(files []renderedFile) ([]byte, error) {
+	m := Manifest{
+		Version: manifestVersion,
+		Tool:    "openapi-rpc-codegen " + version.String(),
+		Files:   map[string]string{},
+	}
+	for _, f := range files {
+		m.Files[f.rel] = hashContent(f.content)
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// diskHash returns the hash of the file at p, or "" if it doesn't exist.
+func diskHash(p string) (string, error) {
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read existing: %w", err)
+	}
+	return hashContent(data), nil
+}
+
+type writePlan struct {
+	files     []renderedFile
+	deletes   []renderedFile // content unused
+	manifests []renderedFile
+}
+
+// planWrites groups files by output dir and, using each dir's previous
+// manifest, decides what to delete. Files edited by hand since they were
+// generated are never overwritten or deleted unless force is set.
+func planWrites(files []renderedFile, force bool) (*writePlan, error) {
+	byDir := map[string][]renderedFile{}
+	var dirs []string
+	for _, f := range files {
+		if _, ok := byDir[f.dir]; !ok {
+			dirs = append(dirs, f.dir)
+		}
+		byDir[f.dir] = append(byDir[f.dir], f)
+	}
+	sort.Strings(dirs)
+
+	plan := &writePlan{files: files}
+	var edited []string
+	for _, dir := range dirs {
+		prev, err := readManifest(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		produced := map[string]bool{}
+		for _, f := range byDir[dir] {
+			produced[f.rel] = true
+			if prev == nil || force {
+				continue
+			}
+			cur, err := diskHash(f.path)
+			if err != nil {
+				return nil, err
+			}
+			if cur == "" || cur == hashContent(f.content) {
+				continue
+			}
+			// overwrite only what this tool wrote last time
+			if recorded, ok := prev.Files[f.rel]; !ok || recorded != cur {
+				edited = append(edited, f.path)
+			}
+		}
+
+		if prev != nil {
+			stale := make([]string, 0, len(prev.Files))
+			for rel := range prev.Files {
+				if !produced[rel] {
+					stale = append(stale, rel)
+				}
+			}
+			sort.Strings(stale)
+			for _, rel := range stale {
+				p := filepath.Join(dir, filepath.FromSlash(rel))
+				cur, err := diskHash(p)
+				if err != nil {
+					return nil, err
+				}
+				if cur == "" {
+					continue
+				}
+				if cur != prev.Files[rel] && !force {
+					edited = append(edited, p)
+					continue
+				}
+				plan.deletes = append(plan.deletes, renderedFile{dir: dir, rel: rel, path: p})
+			}
+		}
+
+		data, err := newManifest(byDir[dir])
+		if err != nil {
+			return nil, err
+		}
+		plan.manifests = append(plan.manifests, renderedFile{
+			dir:     dir,
+			rel:     ManifestName,
+			path:    filepath.Join(dir, ManifestName),
+			content: data,
+		})
+	}
+
+	if len(edited) > 0 {
+		return nil, fmt.Errorf("refusing to overwrite or delete files edited since generation (use -force):\n  %s", strings.Join(edited, "\n  "))
+	}
+	return plan, nil
+}
@@ -0,0 +1,74 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+type EmitOptions struct {
+	Name    string            // plugin name, e.g. "kotlin"
+	Options map[string]string // passed through to the plugin
+}
+
+// Emit runs an external emitter plugin and returns the files it produced.
+func Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	if opt.Name == "" {
+		return nil, fmt.Errorf("plugin name is empty")
+	}
+
+	exe, err := exec.LookPath(ExecutablePrefix + opt.Name)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", opt.Name, err)
+	}
+
+	irDoc, err := ir.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	req, err := json.Marshal(Request{
+		Version: ProtocolVersion,
+		Target:  "plugin:" + opt.Name,
+		Options: opt.Options,
+		IR:      irDoc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: encode request: %w", opt.Name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(exe)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	runErr := cmd.Run()
+
+	// A plugin that fails through RunPlugin writes its error in the response
+	// and then exits non-zero, so the response is decoded first.
+	var resp Response
+	decodeErr := json.Unmarshal(stdout.Bytes(), &resp)
+	switch {
+	case decodeErr == nil && resp.Error != "":
+		return nil, fmt.Errorf("plugin %s: %s", opt.Name, resp.Error)
+	case runErr != nil:
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("plugin %s: %w: %s", opt.Name, runErr, msg)
+		}
+		return nil, fmt.Errorf("plugin %s: %w", opt.Name, runErr)
+	case decodeErr != nil:
+		return nil, fmt.Errorf("plugin %s: decode response: %w", opt.Name, decodeErr)
+	}
+
+	files := make([]common.File, 0, len(resp.Files))
+	for _, f := range resp.Files {
+		files = append(files, common.File{Path: f.Path, Content: []byte(f.Content)})
+	}
+	return files, nil
+}
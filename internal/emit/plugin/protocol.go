@@ -0,0 +1,35 @@
+package plugin
+
+import "encoding/json"
+
+// ProtocolVersion is bumped on incompatible changes to Request/Response.
+const ProtocolVersion = 1
+
+// ExecutablePrefix is prepended to the plugin name to find its executable on
+// PATH: target "plugin:kotlin" runs "openapi-rpc-codegen-gen-kotlin".
+const ExecutablePrefix = "openapi-rpc-codegen-gen-"
+
+// Request is written as JSON to the plugin's stdin.
+type Request struct {
+	Version int               `json:"version"`
+	Target  string            `json:"target"` // e.g. "plugin:kotlin"
+	Options map[string]string `json:"options,omitempty"`
+
+	// IR is the raw-ir document (see ir.Marshal).
+	IR json.RawMessage `json:"ir"`
+}
+
+// Response is read as JSON from the plugin's stdout.
+type Response struct {
+	Files []File `json:"files"`
+
+	// Error reports a generation failure; a non-zero exit status does too.
+	Error string `json:"error,omitempty"`
+}
+
+// File is one generated file. Path is slash-separated and relative to the
+// target's output directory (<out>/<name> unless configured otherwise).
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
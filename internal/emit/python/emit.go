@@ -0,0 +1,43 @@
+// Package python emits a standard-library Python client: dataclass models
+// (models.py), one client class per tag (client.py) and a swappable urllib
+// transport (transport.py), laid out as an importable package.
+package python
+
+import (
+	"embed"
+	"text/template"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/*.py.tpl
+var tplFS embed.FS
+
+func Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	data, err := BuildTemplateData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{"pystr": pyString}
+
+	var files []common.File
+	for _, o := range []struct{ tpl, out string }{
+		{"templates/__init__.py.tpl", "__init__.py"},
+		{"templates/models.py.tpl", "models.py"},
+		{"templates/transport.py.tpl", "transport.py"},
+		{"templates/client.py.tpl", "client.py"},
+	} {
+		tpl, err := common.LoadTemplate(tplFS, o.tpl, opt.TemplatesDir, funcs)
+		if err != nil {
+			return nil, err
+		}
+		out, err := common.ExecTemplate(tpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, common.File{Path: o.out, Content: out})
+	}
+	return files, nil
+}
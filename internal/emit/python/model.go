@@ -0,0 +1,563 @@
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+type EmitOptions struct {
+	TemplatesDir string // optional user template overrides
+}
+
+type TemplateData struct {
+	BaseURL string
+	Types   []PyType
+	Tags    []PyTag
+}
+
+type PyType struct {
+	Name string
+	Kind string // "class" | "enum" | "alias"
+
+	// class
+	Fields []PyField
+
+	// enum
+	Members []PyEnumMember
+
+	// alias
+	Alias string
+}
+
+type PyField struct {
+	Name     string // attribute name
+	JSONName string
+	Type     string // annotation
+	Required bool   // no default; always serialized
+	Decode   string // expression reading the field from `data`
+	Encode   string // expression producing the JSON value from `self`
+}
+
+type PyEnumMember struct {
+	Name  string
+	Value string
+}
+
+type PyTag struct {
+	Name   string // class prefix, e.g. "User" -> UserClient
+	Attr   string // attribute on Client, e.g. "user"
+	Routes []PyRoute
+}
+
+type PyRoute struct {
+	Name       string // operationId
+	Method     string // GET/POST
+	MethodName string // snake_case Python method
+	Signature  string // parameters after self
+	ReturnType string
+	PathExpr   string // Python expression building the escaped path
+
+	Query  []PyParam
+	Body   string // JSON body expression, "None" without body
+	Decode string // callable for the response, "None" to use it as is
+}
+
+type PyParam struct {
+	Name     string // Python argument
+	JSONName string
+}
+
+// reserved are argument names the generated methods use themselves.
+var reserved = map[string]bool{"self": true, "body": true, "models": true}
+
+var keywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true, "finally": true,
+	"for": true, "from": true, "global": true, "if": true, "import": true, "in": true,
+	"is": true, "lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true,
+	"raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+func BuildTemplateData(spec *ir.Spec) (*TemplateData, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil IR spec")
+	}
+	m := &mapper{types: spec.Types}
+
+	data := &TemplateData{BaseURL: spec.Meta.BaseURL}
+	types, err := m.buildTypes()
+	if err != nil {
+		return nil, err
+	}
+	data.Types = types
+	tags, err := m.buildTags(spec.Routes)
+	if err != nil {
+		return nil, err
+	}
+	data.Tags = tags
+	return data, nil
+}
+
+type mapper struct {
+	types map[string]ir.TypeDecl
+}
+
+func (m *mapper) buildTypes() ([]PyType, error) {
+	names := make([]string, 0, len(m.types))
+	for n := range m.types {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	out := make([]PyType, 0, len(names))
+	for _, n := range names {
+		td := m.types[n]
+		name := server.GoPublicIdent(td.Name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid type name: %q", td.Name)
+		}
+
+		switch td.Type.Kind {
+		case ir.KindObject:
+			pt := PyType{Name: name, Kind: "class"}
+			used := map[string]bool{"to_dict": true, "from_dict": true}
+			for _, f := range td.Type.Fields {
+				attr := uniqueIdent(snakeIdent(f.Name, "field"), used)
+				nullable := m.nullable(f.Type)
+				typ := m.render(f.Type, "", false)
+				if nullable || !f.Required {
+					typ = optional(typ)
+				}
+				src := fmt.Sprintf("data[%s]", pyString(f.Name))
+				decode := m.decoder(f.Type, "", nil)
+				if !f.Required {
+					src = fmt.Sprintf("data.get(%s)", pyString(f.Name))
+					decode = orNone(decode)
+				}
+				pt.Fields = append(pt.Fields, PyField{
+					Name:     attr,
+					JSONName: f.Name,
+					Type:     typ,
+					Required: f.Required,
+					Decode:   apply(decode, src),
+					Encode:   apply(m.encoder(f.Type, nil), "self."+attr),
+				})
+			}
+			out = append(out, pt)
+		case ir.KindEnum:
+			pt := PyType{Name: name, Kind: "enum"}
+			used := map[string]bool{}
+			for _, v := range td.Type.Enum {
+				pt.Members = append(pt.Members, PyEnumMember{
+					Name:  uniqueIdent(enumMemberName(v), used),
+					Value: v,
+				})
+			}
+			out = append(out, pt)
+		case ir.KindScalar, ir.KindArray:
+			out = append(out, PyType{
+				Name:  name,
+				Kind:  "alias",
+				Alias: m.renderInline(td.Type, "", true),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported type kind: %s", td.Type.Kind)
+		}
+	}
+	return out, nil
+}
+
+func (m *mapper) buildTags(routes []ir.Route) ([]PyTag, error) {
+	groups := server.GroupRoutes(routes)
+	out := make([]PyTag, 0, len(groups))
+	attrs := map[string]bool{}
+	for _, g := range groups {
+		pt := PyTag{Name: g.Name, Attr: uniqueIdent(snakeIdent(g.Name, "tag"), attrs)}
+		methods := map[string]bool{}
+		for _, r := range g.Routes {
+			pr, err := m.toRoute(r, methods)
+			if err != nil {
+				return nil, fmt.Errorf("route %s.%s: %w", g.Name, r.Name, err)
+			}
+			pt.Routes = append(pt.Routes, pr)
+		}
+		out = append(out, pt)
+	}
+	return out, nil
+}
+
+func (m *mapper) toRoute(r ir.Route, methods map[string]bool) (PyRoute, error) {
+	if r.Success.Type == nil {
+		return PyRoute{}, fmt.Errorf("missing 200 response schema")
+	}
+	if r.Method != "GET" && r.Method != "POST" {
+		return PyRoute{}, fmt.Errorf("unsupported method %q", r.Method)
+	}
+	name := snakeIdent(r.Name, "")
+	if name == "" {
+		return PyRoute{}, fmt.Errorf("invalid operationId: %q", r.Name)
+	}
+
+	used := map[string]bool{}
+	for k := range reserved {
+		used[k] = true
+	}
+	var args []string
+
+	pathArgs := map[string]string{}
+	for _, p := range r.PathParams {
+		arg := uniqueIdent(snakeIdent(p.Name, "param"), used)
+		pathArgs[p.Name] = arg
+		args = append(args, fmt.Sprintf("%s: %s", arg, m.render(p.Type, "models.", false)))
+	}
+	pathExpr, err := buildPathExpr(r.Path, pathArgs)
+	if err != nil {
+		return PyRoute{}, err
+	}
+
+	body := "None"
+	if r.Method == "POST" && r.RequestBody != nil {
+		typ := m.render(r.RequestBody.Type, "models.", false)
+		encode := m.encoder(r.RequestBody.Type, nil)
+		if r.RequestBody.Required {
+			args = append(args, "body: "+typ)
+		} else {
+			args = append(args, fmt.Sprintf("body: %s = None", optional(typ)))
+			encode = orNone(encode)
+		}
+		body = apply(encode, "body")
+	}
+
+	var query []PyParam
+	if len(r.QueryParams) > 0 {
+		args = append(args, "*")
+		for _, p := range r.QueryParams {
+			arg := uniqueIdent(snakeIdent(p.Name, "param"), used)
+			typ := m.render(p.Type, "models.", false)
+			if p.Required {
+				args = append(args, fmt.Sprintf("%s: %s", arg, typ))
+			} else {
+				args = append(args, fmt.Sprintf("%s: %s = None", arg, optional(typ)))
+			}
+			query = append(query, PyParam{Name: arg, JSONName: p.Name})
+		}
+	}
+
+	return PyRoute{
+		Name:       r.Name,
+		Method:     r.Method,
+		MethodName: uniqueIdent(name, methods),
+		Signature:  strings.Join(args, ", "),
+		ReturnType: m.render(*r.Success.Type, "models.", false),
+		PathExpr:   pathExpr,
+		Query:      query,
+		Body:       body,
+		Decode:     orDefault(m.decoder(*r.Success.Type, "models.", nil), "None"),
+	}, nil
+}
+
+// buildPathExpr turns "/users/{id}" into `"/users/" + path_escape(id)`.
+func buildPathExpr(p string, args map[string]string) (string, error) {
+	var parts []string
+	rest := p
+	for {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			break
+		}
+		j := strings.Index(rest[i:], "}")
+		if j < 0 {
+			break
+		}
+		if i > 0 {
+			parts = append(parts, pyString(rest[:i]))
+		}
+		name := rest[i+1 : i+j]
+		arg, ok := args[name]
+		if !ok {
+			return "", fmt.Errorf("path parameter %q is not declared", name)
+		}
+		parts = append(parts, "path_escape("+arg+")")
+		rest = rest[i+j+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, pyString(rest))
+	}
+	return strings.Join(parts, " + "), nil
+}
+
+// ---- type rendering ----
+
+// render returns the annotation for tr; refs are prefixed with prefix and,
+// when quote is set, written as forward-reference strings.
+func (m *mapper) render(tr ir.TypeRef, prefix string, quote bool) string {
+	if tr.RefName != "" {
+		name := prefix + server.GoPublicIdent(tr.RefName)
+		if quote {
+			return pyString(name)
+		}
+		return name
+	}
+	if tr.Inline != nil {
+		return m.renderInline(*tr.Inline, prefix, quote)
+	}
+	return "Any"
+}
+
+func (m *mapper) renderInline(t ir.Type, prefix string, quote bool) string {
+	var s string
+	switch t.Kind {
+	case ir.KindScalar:
+		switch t.Scalar {
+		case "string":
+			s = "str"
+		case "number":
+			s = "float"
+		case "integer":
+			s = "int"
+		case "boolean":
+			s = "bool"
+		default:
+			s = "Any"
+		}
+	case ir.KindEnum:
+		if len(t.Enum) == 0 {
+			s = "str"
+			break
+		}
+		vals := make([]string, 0, len(t.Enum))
+		for _, v := range t.Enum {
+			vals = append(vals, pyString(v))
+		}
+		s = "Literal[" + strings.Join(vals, ", ") + "]"
+	case ir.KindArray:
+		elem := "Any"
+		if t.Elem != nil {
+			elem = m.render(*t.Elem, prefix, quote)
+			if m.nullable(*t.Elem) {
+				elem = optional(elem)
+			}
+		}
+		s = "List[" + elem + "]"
+	case ir.KindObject:
+		// inline objects stay plain dicts
+		s = "Dict[str, Any]"
+	default:
+		s = "Any"
+	}
+	if t.Nullable {
+		return optional(s)
+	}
+	return s
+}
+
+// nullable reports whether tr (following refs) admits null.
+func (m *mapper) nullable(tr ir.TypeRef) bool {
+	if tr.Inline != nil {
+		return tr.Inline.Nullable
+	}
+	if td, ok := m.types[tr.RefName]; ok {
+		return td.Type.Nullable
+	}
+	return false
+}
+
+// apply calls fn on expr; an empty fn is the identity.
+func apply(fn, expr string) string {
+	if fn == "" {
+		return expr
+	}
+	return fn + "(" + expr + ")"
+}
+
+// orNone makes fn pass None through.
+func orNone(fn string) string {
+	if fn == "" || strings.HasPrefix(fn, "_nullable(") {
+		return fn
+	}
+	return "_nullable(" + fn + ")"
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+func optional(typ string) string {
+	if strings.HasPrefix(typ, "Optional[") || typ == "Any" {
+		return typ
+	}
+	return "Optional[" + typ + "]"
+}
+
+// decoder returns a callable expression converting decoded JSON into tr, or
+// "" when the JSON value can be used as is.
+func (m *mapper) decoder(tr ir.TypeRef, prefix string, seen map[string]bool) string {
+	var fn string
+	var nullable bool
+	if tr.RefName != "" {
+		td, ok := m.types[tr.RefName]
+		if !ok || seen[tr.RefName] {
+			return ""
+		}
+		name := prefix + server.GoPublicIdent(tr.RefName)
+		nullable = td.Type.Nullable
+		switch td.Type.Kind {
+		case ir.KindObject:
+			fn = name + ".from_dict"
+		case ir.KindEnum:
+			fn = "_enum(" + name + ")"
+		default:
+			next := map[string]bool{tr.RefName: true}
+			for k := range seen {
+				next[k] = true
+			}
+			return m.decoder(ir.TypeRef{Inline: &td.Type}, prefix, next)
+		}
+	} else if tr.Inline != nil {
+		nullable = tr.Inline.Nullable
+		if tr.Inline.Kind == ir.KindArray && tr.Inline.Elem != nil {
+			if elem := m.decoder(*tr.Inline.Elem, prefix, seen); elem != "" {
+				fn = "_list_of(" + elem + ")"
+			}
+		}
+	}
+	if fn != "" && nullable {
+		return "_nullable(" + fn + ")"
+	}
+	return fn
+}
+
+// encoder is the inverse of decoder.
+func (m *mapper) encoder(tr ir.TypeRef, seen map[string]bool) string {
+	var fn string
+	var nullable bool
+	if tr.RefName != "" {
+		td, ok := m.types[tr.RefName]
+		if !ok || seen[tr.RefName] {
+			return ""
+		}
+		nullable = td.Type.Nullable
+		switch td.Type.Kind {
+		case ir.KindObject:
+			fn = "_to_dict"
+		case ir.KindEnum:
+			// str enums serialize as their value
+		default:
+			next := map[string]bool{tr.RefName: true}
+			for k := range seen {
+				next[k] = true
+			}
+			return m.encoder(ir.TypeRef{Inline: &td.Type}, next)
+		}
+	} else if tr.Inline != nil {
+		nullable = tr.Inline.Nullable
+		if tr.Inline.Kind == ir.KindArray && tr.Inline.Elem != nil {
+			if elem := m.encoder(*tr.Inline.Elem, seen); elem != "" {
+				fn = "_list_of(" + elem + ")"
+			}
+		}
+	}
+	if fn != "" && nullable {
+		return "_nullable(" + fn + ")"
+	}
+	return fn
+}
+
+// ---- identifiers ----
+
+// snakeIdent converts a JSON or operation name to a snake_case Python
+// identifier: "userId" -> "user_id", "next-cursor" -> "next_cursor". Names
+// starting with a digit get prefix + "_"; keywords get a trailing "_".
+func snakeIdent(s, prefix string) string {
+	var b strings.Builder
+	runes := []rune(strings.TrimSpace(s))
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	out := strings.Trim(collapseUnderscores(b.String()), "_")
+	if out == "" {
+		return prefix
+	}
+	if out[0] >= '0' && out[0] <= '9' {
+		if prefix == "" {
+			return ""
+		}
+		out = prefix + "_" + out
+	}
+	if keywords[out] {
+		out += "_"
+	}
+	return out
+}
+
+func enumMemberName(v string) string {
+	return strings.ToUpper(snakeIdent(v, "v"))
+}
+
+func collapseUnderscores(s string) string {
+	for strings.Contains(s, "__") {
+		s = strings.ReplaceAll(s, "__", "_")
+	}
+	return s
+}
+
+// uniqueIdent returns name, or name with a numeric suffix if it is taken,
+// and marks the result as used.
+func uniqueIdent(name string, used map[string]bool) string {
+	out := name
+	for i := 2; used[out]; i++ {
+		out = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[out] = true
+	return out
+}
+
+// pyString quotes s as a Python string literal.
+func pyString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\x%02x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
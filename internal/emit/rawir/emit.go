@@ -0,0 +1,15 @@
+package rawir
+
+import (
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+// Emit renders the normalized spec as a versioned JSON document (ir.json).
+func Emit(spec *ir.Spec) ([]common.File, error) {
+	data, err := ir.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	return []common.File{{Path: "ir.json", Content: data}}, nil
+}
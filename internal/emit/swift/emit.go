@@ -0,0 +1,42 @@
+// Package swift emits a Swift client for iOS: Codable models (Models.swift),
+// an async throws API per tag (APIClient.swift) and the protocol-based
+// transport it is built on, URLSession by default (Transport.swift).
+package swift
+
+import (
+	"embed"
+	"text/template"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/*.swift.tpl
+var tplFS embed.FS
+
+func Emit(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	data, err := BuildTemplateData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{"swiftstr": swiftString}
+
+	var files []common.File
+	for _, o := range []struct{ tpl, out string }{
+		{"templates/Models.swift.tpl", "Models.swift"},
+		{"templates/Transport.swift.tpl", "Transport.swift"},
+		{"templates/APIClient.swift.tpl", "APIClient.swift"},
+	} {
+		tpl, err := common.LoadTemplate(tplFS, o.tpl, opt.TemplatesDir, funcs)
+		if err != nil {
+			return nil, err
+		}
+		out, err := common.ExecTemplate(tpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, common.File{Path: o.out, Content: out})
+	}
+	return files, nil
+}
@@ -0,0 +1,542 @@
+package swift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+type EmitOptions struct {
+	TemplatesDir string // optional user template overrides
+}
+
+type TemplateData struct {
+	BaseURL     string
+	Types       []SwiftType
+	Tags        []SwiftTag
+	HasIndirect bool // some field is Boxed; emit the Indirect wrapper
+}
+
+type SwiftType struct {
+	Name string
+	Kind string // "struct" | "enum" | "alias"
+
+	// struct
+	Fields []SwiftField
+
+	// enum
+	Cases   []SwiftCase
+	Unknown string // case decoded for values this client doesn't know
+
+	// alias
+	Alias string
+}
+
+type SwiftField struct {
+	Name     string // property name, backquoted if needed
+	JSONName string
+	CodingAs bool   // CodingKeys case needs an explicit raw value
+	Type     string // Swift type, Optional when nullable or not required
+	BaseType string // Type without the trailing "?", for decode(_:forKey:)
+	Required bool
+	Nullable bool
+
+	// Boxed fields refer back to their own struct (user.friend: User?),
+	// which Swift cannot store inline; the value lives in an Indirect
+	// stored as Storage and Name becomes a computed property.
+	Boxed   bool
+	Storage string
+}
+
+type SwiftCase struct {
+	Name  string
+	Value string
+	Raw   bool // raw value differs from the case name
+}
+
+type SwiftTag struct {
+	Name   string // type prefix, e.g. "User" -> UserAPI
+	Prop   string // property on APIClient, e.g. "user"
+	Routes []SwiftRoute
+}
+
+type SwiftRoute struct {
+	Name       string // operationId
+	Method     string // GET/POST
+	FuncName   string
+	Params     string // parameter list
+	ReturnType string
+	PathExpr   string // Swift expression building the escaped path
+	Query      []SwiftQuery
+	HasBody    bool
+}
+
+type SwiftQuery struct {
+	JSONName string
+	Expr     string // String? expression
+}
+
+// reserved are names the generated API types use themselves.
+var reserved = map[string]bool{"body": true, "conn": true, "query": true}
+
+// noSelf renames self and Self, which even backquoted would shadow the
+// implicit self inside init and methods (or read as the postfix .self).
+func noSelf(name string) string {
+	if name == "self" || name == "Self" {
+		return name + "_"
+	}
+	return name
+}
+
+var keywords = map[string]bool{
+	"associatedtype": true, "class": true, "deinit": true, "enum": true, "extension": true,
+	"fileprivate": true, "func": true, "import": true, "init": true, "inout": true,
+	"internal": true, "let": true, "open": true, "operator": true, "private": true,
+	"precedencegroup": true, "protocol": true, "public": true, "rethrows": true,
+	"static": true, "struct": true, "subscript": true, "typealias": true, "var": true,
+	"break": true, "case": true, "catch": true, "continue": true, "default": true,
+	"defer": true, "do": true, "else": true, "fallthrough": true, "for": true, "guard": true,
+	"if": true, "in": true, "repeat": true, "return": true, "throw": true, "switch": true,
+	"where": true, "while": true, "Any": true, "as": true, "await": true, "false": true,
+	"is": true, "nil": true, "self": true, "Self": true, "super": true, "throws": true,
+	"true": true, "try": true,
+}
+
+func BuildTemplateData(spec *ir.Spec) (*TemplateData, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil IR spec")
+	}
+	m := &mapper{types: spec.Types}
+
+	data := &TemplateData{BaseURL: spec.Meta.BaseURL}
+	types, err := m.buildTypes()
+	if err != nil {
+		return nil, err
+	}
+	data.Types = types
+	for _, t := range types {
+		for _, f := range t.Fields {
+			data.HasIndirect = data.HasIndirect || f.Boxed
+		}
+	}
+	if data.HasIndirect {
+		if _, ok := spec.Types["Indirect"]; ok {
+			return nil, fmt.Errorf("schema %q conflicts with the Indirect wrapper generated for recursive types", "Indirect")
+		}
+	}
+	tags, err := m.buildTags(spec.Routes)
+	if err != nil {
+		return nil, err
+	}
+	data.Tags = tags
+	return data, nil
+}
+
+type mapper struct {
+	types map[string]ir.TypeDecl
+}
+
+func (m *mapper) buildTypes() ([]SwiftType, error) {
+	names := make([]string, 0, len(m.types))
+	for n := range m.types {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	out := make([]SwiftType, 0, len(names))
+	for _, n := range names {
+		td := m.types[n]
+		name := server.GoPublicIdent(td.Name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid type name: %q", td.Name)
+		}
+
+		switch td.Type.Kind {
+		case ir.KindObject:
+			st := SwiftType{Name: name, Kind: "struct"}
+			used := map[string]bool{}
+			for _, f := range td.Type.Fields {
+				prop := uniqueIdent(noSelf(camelIdent(f.Name, "field")), used)
+				base := strings.TrimSuffix(m.render(f.Type), "?")
+				nullable := m.nullable(f.Type)
+				typ := base
+				if nullable || !f.Required {
+					typ += "?"
+				}
+				boxed := m.cyclic(n, f.Type)
+				storage := ""
+				if boxed {
+					storage = "_" + prop
+				}
+				st.Fields = append(st.Fields, SwiftField{
+					Name:     quoteKeyword(prop),
+					JSONName: f.Name,
+					CodingAs: prop != f.Name,
+					Type:     typ,
+					BaseType: base,
+					Required: f.Required,
+					Nullable: nullable,
+					Boxed:    boxed,
+					Storage:  storage,
+				})
+			}
+			out = append(out, st)
+		case ir.KindEnum:
+			st := SwiftType{Name: name, Kind: "enum"}
+			used := map[string]bool{}
+			for _, v := range td.Type.Enum {
+				c := uniqueIdent(noSelf(camelIdent(v, "value")), used)
+				st.Cases = append(st.Cases, SwiftCase{
+					Name:  quoteKeyword(c),
+					Value: v,
+					Raw:   c != v,
+				})
+			}
+			// the case's implicit raw value must not be a real value either
+			for _, v := range td.Type.Enum {
+				used[v] = true
+			}
+			st.Unknown = uniqueIdent("unknown", used)
+			out = append(out, st)
+		case ir.KindScalar, ir.KindArray:
+			out = append(out, SwiftType{
+				Name:  name,
+				Kind:  "alias",
+				Alias: m.renderInline(td.Type),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported type kind: %s", td.Type.Kind)
+		}
+	}
+	return out, nil
+}
+
+func (m *mapper) buildTags(routes []ir.Route) ([]SwiftTag, error) {
+	groups := server.GroupRoutes(routes)
+	out := make([]SwiftTag, 0, len(groups))
+	props := map[string]bool{}
+	for _, g := range groups {
+		st := SwiftTag{Name: g.Name, Prop: quoteKeyword(uniqueIdent(camelIdent(g.Name, "tag"), props))}
+		funcs := map[string]bool{}
+		for _, r := range g.Routes {
+			sr, err := m.toRoute(r, funcs)
+			if err != nil {
+				return nil, fmt.Errorf("route %s.%s: %w", g.Name, r.Name, err)
+			}
+			st.Routes = append(st.Routes, sr)
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func (m *mapper) toRoute(r ir.Route, funcs map[string]bool) (SwiftRoute, error) {
+	if r.Success.Type == nil {
+		return SwiftRoute{}, fmt.Errorf("missing 200 response schema")
+	}
+	if r.Method != "GET" && r.Method != "POST" {
+		return SwiftRoute{}, fmt.Errorf("unsupported method %q", r.Method)
+	}
+	name := camelIdent(r.Name, "")
+	if name == "" {
+		return SwiftRoute{}, fmt.Errorf("invalid operationId: %q", r.Name)
+	}
+
+	used := map[string]bool{}
+	for k := range reserved {
+		used[k] = true
+	}
+	var params []string
+
+	pathArgs := map[string]string{}
+	for _, p := range r.PathParams {
+		arg := quoteKeyword(uniqueIdent(noSelf(camelIdent(p.Name, "param")), used))
+		pathArgs[p.Name] = `"\(` + arg + `)"`
+		if m.isEnumRef(p.Type) {
+			pathArgs[p.Name] = arg + ".rawValue"
+		}
+		params = append(params, fmt.Sprintf("%s: %s", arg, m.render(p.Type)))
+	}
+	pathExpr, err := buildPathExpr(r.Path, pathArgs)
+	if err != nil {
+		return SwiftRoute{}, err
+	}
+
+	hasBody := r.Method == "POST" && r.RequestBody != nil
+	if hasBody {
+		typ := m.render(r.RequestBody.Type)
+		if r.RequestBody.Required {
+			params = append(params, "body: "+typ)
+		} else {
+			params = append(params, fmt.Sprintf("body: %s = nil", optional(typ)))
+		}
+	}
+
+	var query []SwiftQuery
+	for _, p := range r.QueryParams {
+		arg := quoteKeyword(uniqueIdent(noSelf(camelIdent(p.Name, "param")), used))
+		typ := m.render(p.Type)
+		format := `"\(%s)"`
+		if m.isEnumRef(p.Type) {
+			format = "%s.rawValue"
+		}
+		var expr string
+		if p.Required && !strings.HasSuffix(typ, "?") {
+			params = append(params, fmt.Sprintf("%s: %s", arg, typ))
+			expr = fmt.Sprintf(format, arg)
+		} else {
+			params = append(params, fmt.Sprintf("%s: %s = nil", arg, optional(typ)))
+			expr = fmt.Sprintf("%s.map { %s }", arg, fmt.Sprintf(format, "$0"))
+		}
+		query = append(query, SwiftQuery{JSONName: p.Name, Expr: expr})
+	}
+
+	return SwiftRoute{
+		Name:       r.Name,
+		Method:     r.Method,
+		FuncName:   quoteKeyword(uniqueIdent(noSelf(name), funcs)),
+		Params:     strings.Join(params, ", "),
+		ReturnType: m.render(*r.Success.Type),
+		PathExpr:   pathExpr,
+		Query:      query,
+		HasBody:    hasBody,
+	}, nil
+}
+
+// buildPathExpr turns "/users/{id}" into `"/users/" + pathEscape("\(id)")`;
+// values maps parameter names to String expressions.
+func buildPathExpr(p string, values map[string]string) (string, error) {
+	var parts []string
+	rest := p
+	for {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			break
+		}
+		j := strings.Index(rest[i:], "}")
+		if j < 0 {
+			break
+		}
+		if i > 0 {
+			parts = append(parts, swiftString(rest[:i]))
+		}
+		name := rest[i+1 : i+j]
+		value, ok := values[name]
+		if !ok {
+			return "", fmt.Errorf("path parameter %q is not declared", name)
+		}
+		parts = append(parts, "pathEscape("+value+")")
+		rest = rest[i+j+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, swiftString(rest))
+	}
+	return strings.Join(parts, " + "), nil
+}
+
+// ---- type rendering ----
+
+// render returns the Swift type of tr; inline objects and untyped values
+// become JSONValue, like `any` in go-server.
+func (m *mapper) render(tr ir.TypeRef) string {
+	if tr.RefName != "" {
+		t := server.GoPublicIdent(tr.RefName)
+		if m.nullable(tr) {
+			return optional(t)
+		}
+		return t
+	}
+	if tr.Inline != nil {
+		return m.renderInline(*tr.Inline)
+	}
+	return "JSONValue"
+}
+
+func (m *mapper) renderInline(t ir.Type) string {
+	var s string
+	switch t.Kind {
+	case ir.KindScalar:
+		switch t.Scalar {
+		case "string":
+			s = "String"
+		case "number":
+			s = "Double"
+		case "integer":
+			s = "Int64"
+		case "boolean":
+			s = "Bool"
+		default:
+			s = "JSONValue"
+		}
+	case ir.KindEnum:
+		// inline enums stay strings, as in go-server
+		s = "String"
+	case ir.KindArray:
+		elem := "JSONValue"
+		if t.Elem != nil {
+			elem = m.render(*t.Elem)
+		}
+		s = "[" + elem + "]"
+	default:
+		s = "JSONValue"
+	}
+	if t.Nullable {
+		return optional(s)
+	}
+	return s
+}
+
+// cyclic reports whether a field of object owner with type tr stores an
+// object that (through further fields) stores owner again. Arrays are
+// heap-allocated already, so only direct refs count.
+func (m *mapper) cyclic(owner string, tr ir.TypeRef) bool {
+	seen := map[string]bool{}
+	var reaches func(name string) bool
+	reaches = func(name string) bool {
+		if name == owner {
+			return true
+		}
+		td, ok := m.types[name]
+		if !ok || td.Type.Kind != ir.KindObject || seen[name] {
+			return false
+		}
+		seen[name] = true
+		for _, f := range td.Type.Fields {
+			if f.Type.RefName != "" && reaches(f.Type.RefName) {
+				return true
+			}
+		}
+		return false
+	}
+	return tr.RefName != "" && reaches(tr.RefName)
+}
+
+// nullable reports whether tr (following refs) admits null.
+func (m *mapper) nullable(tr ir.TypeRef) bool {
+	if tr.Inline != nil {
+		return tr.Inline.Nullable
+	}
+	if td, ok := m.types[tr.RefName]; ok {
+		return td.Type.Nullable
+	}
+	return false
+}
+
+func (m *mapper) isEnumRef(tr ir.TypeRef) bool {
+	td, ok := m.types[tr.RefName]
+	return ok && td.Type.Kind == ir.KindEnum
+}
+
+func optional(typ string) string {
+	if strings.HasSuffix(typ, "?") {
+		return typ
+	}
+	return typ + "?"
+}
+
+// ---- identifiers ----
+
+// camelIdent converts a JSON or operation name to a lowerCamelCase Swift
+// identifier: "next-cursor" -> "nextCursor", "user_id" -> "userId". Names
+// starting with a digit get prefix.
+func camelIdent(s, prefix string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return prefix
+	}
+	var b strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+	}
+	out := b.String()
+	if out[0] >= '0' && out[0] <= '9' {
+		if prefix == "" {
+			return ""
+		}
+		out = prefix + out
+	}
+	return out
+}
+
+// splitWords splits on non-alphanumerics and lower-to-upper case changes:
+// "userID" -> [user ID], "next-cursor" -> [next cursor].
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	for i, r := range runes {
+		if r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return words
+}
+
+func quoteKeyword(name string) string {
+	if keywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// uniqueIdent returns name, or name with a numeric suffix if it is taken,
+// and marks the result as used.
+func uniqueIdent(name string, used map[string]bool) string {
+	out := name
+	for i := 2; used[out]; i++ {
+		out = fmt.Sprintf("%s%d", name, i)
+	}
+	used[out] = true
+	return out
+}
+
+// swiftString quotes s as a Swift string literal.
+func swiftString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u{%x}`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
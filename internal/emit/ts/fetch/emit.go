@@ -0,0 +1,30 @@
+// Package fetch emits the fetch-based TypeScript transport for browsers and
+// Node. Types and client are shared with ts-wx (see package wx), so every TS
+// target produces identical types.gen.ts and client.gen.ts.
+package fetch
+
+import (
+	"embed"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/ts/wx"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/fetch_transport.ts.tpl
+var transportTplFS embed.FS
+
+func EmitTransport(spec *ir.Spec, opt wx.EmitOptions) ([]common.File, error) {
+	_ = spec
+
+	tpl, err := common.LoadTemplate(transportTplFS, "templates/fetch_transport.ts.tpl", opt.TemplatesDir, nil)
+	if err != nil {
+		return nil, err
+	}
+	out, err := common.ExecTemplate(tpl, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return []common.File{{Path: "transport.ts", Content: out}}, nil
+}
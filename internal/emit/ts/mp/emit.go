@@ -0,0 +1,64 @@
+// Package mp emits the TypeScript transport for mini-program platforms: a
+// shared core (transport.ts) and a small per-platform adapter (adapter.ts)
+// around the platform's request API. Types and client come from package wx.
+package mp
+
+import (
+	"embed"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/*.ts.tpl
+var tplFS embed.FS
+
+// Platforms lists the supported platform adapters.
+var Platforms = []string{"wx", "alipay", "douyin", "uni"}
+
+type EmitOptions struct {
+	Target       string // named in the generated-file header
+	Platform     string // one of Platforms, default "wx"
+	TemplatesDir string // optional user template overrides
+}
+
+type TransportTemplateData struct {
+	Target   string
+	Platform string
+}
+
+func EmitTransport(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	_ = spec
+
+	if opt.Platform == "" {
+		opt.Platform = "wx"
+	}
+	if !IsPlatform(opt.Platform) {
+		return nil, fmt.Errorf("unknown platform %q (known: %s)", opt.Platform, strings.Join(Platforms, ", "))
+	}
+	data := TransportTemplateData{Target: opt.Target, Platform: opt.Platform}
+
+	var files []common.File
+	for _, o := range []struct{ tpl, out string }{
+		{"templates/transport.ts.tpl", "transport.ts"},
+		{"templates/adapter_" + opt.Platform + ".ts.tpl", "adapter.ts"},
+	} {
+		tpl, err := common.LoadTemplate(tplFS, o.tpl, opt.TemplatesDir, nil)
+		if err != nil {
+			return nil, err
+		}
+		out, err := common.ExecTemplate(tpl, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, common.File{Path: o.out, Content: out})
+	}
+	return files, nil
+}
+
+func IsPlatform(p string) bool {
+	return slices.Contains(Platforms, p)
+}
@@ -0,0 +1,33 @@
+// Package node emits a TypeScript server for node:http: a <Tag>Service
+// interface per tag, mirroring go-server, and a dispatcher that decodes path,
+// query and body with the same rules as the Go handlers. types.gen.ts is
+// shared with the TS clients (see package wx).
+package node
+
+import (
+	"embed"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/server.ts.tpl
+var serverTplFS embed.FS
+
+func EmitServer(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
+	data, err := BuildServerData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := common.LoadTemplate(serverTplFS, "templates/server.ts.tpl", opt.TemplatesDir, nil)
+	if err != nil {
+		return nil, err
+	}
+	out, err := common.ExecTemplate(tpl, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return []common.File{{Path: "server.gen.ts", Content: out}}, nil
+}
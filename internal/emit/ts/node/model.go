@@ -0,0 +1,282 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/ts/wx"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+type EmitOptions struct {
+	TemplatesDir string // optional user template overrides
+}
+
+type ServerTemplateData struct {
+	Tags          []ServerTag
+	Routes        []ServerRoute // all routes in match order
+	HasErrorCodes bool          // types.gen.ts declares ErrorCode and ErrorStatus
+}
+
+type ServerTag struct {
+	Name   string // <Tag>Service and the Services key, as in go-server
+	Routes []ServerRoute
+}
+
+type ServerRoute struct {
+	Name        string // operationId, also the service method name
+	Method      string // GET/POST
+	Path        string
+	Pattern     string // JS regex literal matching the raw path
+	TagName     string
+	HandlerName string // e.g. "handleGetUser"
+
+	PathType   string // e.g. "GetUserPath", "" without path params
+	QueryType  string // e.g. "GetUserQuery", "" without query params
+	BodyType   string // TS type, "" without body
+	ReturnType string
+
+	PathFields  []PathField
+	QueryFields []QueryField
+}
+
+type PathField struct {
+	Prop  string // property name, quoted if needed
+	Index int    // capture group of Pattern, from 0
+}
+
+type QueryField struct {
+	Prop      string // property name, quoted if needed
+	Access    string // `.name` or `["name"]`
+	JSONName  string
+	Type      string // TS type of the property
+	Required  bool
+	ParseKind string // string|int64|float64|bool, as in go-server
+}
+
+func BuildServerData(spec *ir.Spec) (*ServerTemplateData, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil IR spec")
+	}
+	data := &ServerTemplateData{HasErrorCodes: len(spec.Meta.ErrorCodes) > 0}
+	for _, g := range server.GroupRoutes(spec.Routes) {
+		st := ServerTag{Name: g.Name}
+		for _, r := range g.Routes {
+			sr, err := toServerRoute(g.Name, r, spec.Types)
+			if err != nil {
+				return nil, fmt.Errorf("route %s.%s: %w", g.Name, r.Name, err)
+			}
+			st.Routes = append(st.Routes, sr)
+			data.Routes = append(data.Routes, sr)
+		}
+		data.Tags = append(data.Tags, st)
+	}
+	sort.SliceStable(data.Routes, func(i, j int) bool {
+		return lessSpecific(data.Routes[i].Path, data.Routes[j].Path)
+	})
+	return data, nil
+}
+
+func toServerRoute(tag string, r ir.Route, types map[string]ir.TypeDecl) (ServerRoute, error) {
+	if r.Success.Type == nil {
+		return ServerRoute{}, fmt.Errorf("missing 200 response schema")
+	}
+	if r.Method != "GET" && r.Method != "POST" {
+		return ServerRoute{}, fmt.Errorf("unsupported method %q", r.Method)
+	}
+	op := server.GoPublicIdent(r.Name)
+	if op == "" {
+		return ServerRoute{}, fmt.Errorf("invalid operationId: %q", r.Name)
+	}
+
+	sr := ServerRoute{
+		Name:        r.Name,
+		Method:      r.Method,
+		Path:        r.Path,
+		TagName:     tag,
+		HandlerName: "handle" + op,
+		ReturnType:  wx.RenderTypeRef(*r.Success.Type),
+	}
+
+	pattern, names, err := compilePath(r.Path)
+	if err != nil {
+		return ServerRoute{}, err
+	}
+	sr.Pattern = pattern
+	declared := map[string]bool{}
+	for _, p := range r.PathParams {
+		declared[p.Name] = true
+	}
+	for i, n := range names {
+		if !declared[n] {
+			return ServerRoute{}, fmt.Errorf("path parameter %q is not declared", n)
+		}
+		sr.PathFields = append(sr.PathFields, PathField{Prop: prop(n), Index: i})
+	}
+	if len(r.PathParams) > 0 {
+		sr.PathType = op + "Path"
+	}
+
+	for _, p := range r.QueryParams {
+		kind, ok := scalarKind(p.Type, types)
+		if !ok {
+			return ServerRoute{}, fmt.Errorf("unsupported query param type %q", p.Name)
+		}
+		access := "." + p.Name
+		if !wx.IsSafeProp(p.Name) {
+			access = fmt.Sprintf("[%q]", p.Name)
+		}
+		sr.QueryFields = append(sr.QueryFields, QueryField{
+			Prop:      prop(p.Name),
+			Access:    access,
+			JSONName:  p.Name,
+			Type:      wx.RenderTypeRef(p.Type),
+			Required:  p.Required,
+			ParseKind: kind,
+		})
+	}
+	if len(r.QueryParams) > 0 {
+		sr.QueryType = op + "Query"
+	}
+
+	if r.Method == "POST" && r.RequestBody != nil {
+		sr.BodyType = wx.RenderTypeRef(r.RequestBody.Type)
+	}
+	return sr, nil
+}
+
+// ServiceMethod renders the <Tag>Service method signature; like go-server
+// the context comes first and path, query and body only when present.
+func (r ServerRoute) ServiceMethod() string {
+	args := []string{"ctx: Context"}
+	if r.PathType != "" {
+		args = append(args, "path: "+r.PathType)
+	}
+	if r.QueryType != "" {
+		args = append(args, "query: "+r.QueryType)
+	}
+	if r.BodyType != "" {
+		args = append(args, "body: "+r.BodyType)
+	}
+	return fmt.Sprintf("%s(%s): Promise<%s> | %s", prop(r.Name), strings.Join(args, ", "), r.ReturnType, r.ReturnType)
+}
+
+// CallArgs is the argument list the handler passes to the service method.
+func (r ServerRoute) CallArgs() string {
+	args := []string{"ctx"}
+	if r.PathType != "" {
+		args = append(args, "path")
+	}
+	if r.QueryType != "" {
+		args = append(args, "query")
+	}
+	if r.BodyType != "" {
+		args = append(args, "body")
+	}
+	return strings.Join(args, ", ")
+}
+
+// MethodAccess is the property access calling the service method.
+func (r ServerRoute) MethodAccess() string {
+	if wx.IsSafeProp(r.Name) {
+		return "." + r.Name
+	}
+	return fmt.Sprintf("[%q]", r.Name)
+}
+
+// compilePath turns "/users/{id}" into the regex literal
+// `/^\/users\/([^/]+)$/` and the parameter names in capture order. Like chi,
+// a parameter matches one non-empty path segment.
+func compilePath(p string) (string, []string, error) {
+	var b strings.Builder
+	var names []string
+	b.WriteString("/^")
+	rest := p
+	for {
+		i := strings.Index(rest, "{")
+		if i < 0 {
+			break
+		}
+		j := strings.Index(rest[i:], "}")
+		if j < 0 {
+			return "", nil, fmt.Errorf("unterminated path parameter in %q", p)
+		}
+		b.WriteString(escapeRegexp(rest[:i]))
+		names = append(names, rest[i+1:i+j])
+		b.WriteString("([^/]+)")
+		rest = rest[i+j+1:]
+	}
+	b.WriteString(escapeRegexp(rest))
+	b.WriteString("$/")
+	return b.String(), names, nil
+}
+
+func escapeRegexp(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`\^$.*+?()[]{}|/`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lessSpecific orders paths so that, segment by segment, static segments are
+// tried before parameters, which is how chi resolves "/users/me" against
+// "/users/{id}". Segments of the same kind compare lexically and a prefix
+// sorts first, so this is a total order and the sort is deterministic.
+func lessSpecific(a, b string) bool {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		ap, bp := strings.Contains(as[i], "{"), strings.Contains(bs[i], "{")
+		if ap != bp {
+			return bp
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) < len(bs)
+}
+
+func scalarKind(tr ir.TypeRef, types map[string]ir.TypeDecl) (string, bool) {
+	t := tr.Inline
+	if tr.RefName != "" {
+		td, ok := types[tr.RefName]
+		if !ok {
+			return "", false
+		}
+		t = &td.Type
+	}
+	if t == nil {
+		return "", false
+	}
+	switch t.Kind {
+	case ir.KindEnum:
+		return "string", true
+	case ir.KindScalar:
+		switch t.Scalar {
+		case "integer":
+			return "int64", true
+		case "number":
+			return "float64", true
+		case "boolean":
+			return "bool", true
+		default:
+			return "string", true
+		}
+	default:
+		return "", false
+	}
+}
+
+func prop(name string) string {
+	if wx.IsSafeProp(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
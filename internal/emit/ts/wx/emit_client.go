@@ -1,11 +1,7 @@
 package wx
 
 import (
-	"bytes"
 	"embed"
-	"fmt"
-	"path/filepath"
-	"text/template"
 
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
@@ -14,34 +10,23 @@ import (
 //go:embed templates/client.ts.tpl
 var clientTplFS embed.FS
 
-func EmitClient(spec *ir.Spec, opt EmitOptions) ([]string, error) {
+func EmitClient(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
 	data, err := BuildClientData(spec)
 	if err != nil {
 		return nil, err
 	}
-
-	tplText, err := clientTplFS.ReadFile("templates/client.ts.tpl")
-	if err != nil {
-		return nil, fmt.Errorf("read client template: %w", err)
+	if opt.ClientFactory != "" {
+		data.Factory = opt.ClientFactory
 	}
 
-	tpl, err := template.New("client").Parse(string(tplText))
+	tpl, err := common.LoadTemplate(clientTplFS, "templates/client.ts.tpl", opt.TemplatesDir, nil)
 	if err != nil {
-		return nil, fmt.Errorf("parse client template: %w", err)
-	}
-
-	var buf bytes.Buffer
-	if err := tpl.Execute(&buf, data); err != nil {
-		return nil, fmt.Errorf("exec client template: %w", err)
+		return nil, err
 	}
-
-	outPath := filepath.Join(opt.OutDir, "ts-wx", "client.gen.ts")
-	wrote, err := common.WriteFile(outPath, buf.Bytes(), common.WriteOptions{Check: opt.Check})
+	out, err := common.ExecTemplate(tpl, data)
 	if err != nil {
 		return nil, err
 	}
-	if wrote {
-		return []string{outPath}, nil
-	}
-	return []string{}, nil
+
+	return []common.File{{Path: "client.gen.ts", Content: out}}, nil
 }
@@ -1,10 +1,7 @@
 package wx
 
 import (
-	"bytes"
 	"embed"
-	"fmt"
-	"path/filepath"
 	"text/template"
 
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
@@ -14,17 +11,12 @@ import (
 //go:embed templates/types.ts.tpl
 var typesTplFS embed.FS
 
-func EmitTypes(spec *ir.Spec, opt EmitOptions) ([]string, error) {
+func EmitTypes(spec *ir.Spec, opt EmitOptions) ([]common.File, error) {
 	data, err := BuildTypesData(spec)
 	if err != nil {
 		return nil, err
 	}
 
-	tplText, err := typesTplFS.ReadFile("templates/types.ts.tpl")
-	if err != nil {
-		return nil, fmt.Errorf("read types template: %w", err)
-	}
-
 	funcs := template.FuncMap{
 		"enumUnion": func(vals []string) string {
 			qs := quoteUnion(vals)
@@ -38,23 +30,14 @@ func EmitTypes(spec *ir.Spec, opt EmitOptions) ([]string, error) {
 		"isSafeProp": isSafeTSProp,
 	}
 
-	tpl, err := template.New("types").Funcs(funcs).Parse(string(tplText))
+	tpl, err := common.LoadTemplate(typesTplFS, "templates/types.ts.tpl", opt.TemplatesDir, funcs)
 	if err != nil {
-		return nil, fmt.Errorf("parse types template: %w", err)
-	}
-
-	var buf bytes.Buffer
-	if err := tpl.Execute(&buf, data); err != nil {
-		return nil, fmt.Errorf("exec types template: %w", err)
+		return nil, err
 	}
-
-	outPath := filepath.Join(opt.OutDir, "ts-wx", "types.gen.ts")
-	wrote, err := common.WriteFile(outPath, buf.Bytes(), common.WriteOptions{Check: opt.Check})
+	out, err := common.ExecTemplate(tpl, data)
 	if err != nil {
 		return nil, err
 	}
-	if wrote {
-		return []string{outPath}, nil
-	}
-	return []string{}, nil
+
+	return []common.File{{Path: "types.gen.ts", Content: out}}, nil
 }
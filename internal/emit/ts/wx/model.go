@@ -9,12 +9,19 @@ import (
 )
 
 type EmitOptions struct {
-	OutDir string
-	Check  bool
+	ClientFactory string // default "makeApi"
+	TemplatesDir  string // optional user template overrides
 }
 
 type TypesTemplateData struct {
-	Types []NamedType
+	Types      []NamedType
+	ErrorCodes []ErrorCode // x-error-codes; the ErrorCode union is only emitted when non-empty
+}
+
+type ErrorCode struct {
+	Code        string
+	Status      int
+	Description string
 }
 
 type NamedType struct {
@@ -39,7 +46,8 @@ type Field struct {
 }
 
 type ClientTemplateData struct {
-	Tags []ClientTag
+	Factory string // exported factory function, e.g. "makeApi"
+	Tags    []ClientTag
 }
 
 type ClientTag struct {
@@ -76,6 +84,20 @@ func BuildTypesData(spec *ir.Spec) (*TypesTemplateData, error) {
 		}
 		out.Types = append(out.Types, nt)
 	}
+	for _, ec := range spec.Meta.ErrorCodes {
+		out.ErrorCodes = append(out.ErrorCodes, ErrorCode{
+			Code:        ec.Code,
+			Status:      ec.Status,
+			Description: strings.Join(strings.Fields(ec.Description), " "),
+		})
+	}
+	if len(out.ErrorCodes) > 0 {
+		for _, nt := range out.Types {
+			if nt.Name == "ErrorCode" || nt.Name == "ErrorStatus" {
+				return nil, fmt.Errorf("schema %q conflicts with the type generated for x-error-codes", nt.Name)
+			}
+		}
+	}
 	return out, nil
 }
 
@@ -137,7 +159,7 @@ func BuildClientData(spec *ir.Spec) (*ClientTemplateData, error) {
 	}
 	sort.Strings(tags)
 
-	data := &ClientTemplateData{Tags: make([]ClientTag, 0, len(tags))}
+	data := &ClientTemplateData{Factory: "makeApi", Tags: make([]ClientTag, 0, len(tags))}
 	for _, t := range tags {
 		rs := byTag[t]
 		sort.Slice(rs, func(i, j int) bool { return rs[i].Name < rs[j].Name })
@@ -161,10 +183,7 @@ func toClientRoute(r ir.Route) (ClientRoute, error) {
 		return ClientRoute{}, fmt.Errorf("success schema missing (Scheme A requires 200 JSON schema)")
 	}
 
-	ret := "T." + sanitizeTSIdent(renderTypeRefName(*r.Success.Type))
-	if ret == "T." {
-		ret = "unknown"
-	}
+	ret := renderTypeRefAsTS(*r.Success.Type)
 
 	// signature:
 	// POST with body: (body: X, path?: {...}, query?: {...})
@@ -243,14 +262,6 @@ func tsAccess(name string) string {
 	return "[" + fmt.Sprintf("%q", name) + "]"
 }
 
-func renderTypeRefName(tr ir.TypeRef) string {
-	if tr.RefName != "" {
-		return tr.RefName
-	}
-	// inline types don't have names; for return type in Scheme A we expect refs most of the time
-	return "unknown"
-}
-
 // --- TS type rendering (shared with both emitters) ---
 
 func renderTypeRefAsTS(tr ir.TypeRef) string {
@@ -369,3 +380,15 @@ func isSafeTSProp(s string) bool {
 	}
 	return true
 }
+
+// RenderTypeRef renders tr as a TS type, referencing named types through the
+// `T` namespace import of types.gen.ts. Other TS emitters use it so that
+// every target spells types the same way.
+func RenderTypeRef(tr ir.TypeRef) string {
+	return renderTypeRefAsTS(tr)
+}
+
+// IsSafeProp reports whether s can be written as a bare TS property name.
+func IsSafeProp(s string) bool {
+	return isSafeTSProp(s)
+}
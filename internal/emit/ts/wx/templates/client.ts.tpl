@@ -1,24 +1,27 @@
 /* AUTO-GENERATED FILE - DO NOT EDIT */
 
 import { rpcRequest, RpcError } from "./transport";
+import type { CallOptions, ClientOptions } from "./transport";
 import * as T from "./types.gen";
 
-export function makeApi(baseURL: string, options?: { headers?: Record<string, string> }) {
+export function {{ .Factory }}(baseURL: string, options?: ClientOptions) {
   const headers = options?.headers;
 
   return {
   {{- range .Tags }}
     {{ .Name }}: {
     {{- range .Routes }}
-      {{ .Name }}: async ({{ .Signature }}): Promise<{{ .ReturnType }}> => {
+    {{- block "clientMethod" . }}
+      {{ .Name }}: async ({{ .Signature }}{{ if .Signature }}, {{ end }}call?: CallOptions): Promise<{{ .ReturnType }}> => {
         const urlPath = {{ .PathExpr }};
         return rpcRequest<{{ .ReturnType }}>(baseURL, "{{ .Method }}", urlPath, {
           query: {{ .QueryVar }},
           body: {{ .BodyVar }},
           headers,
-        });
+        }, options, call);
       },
     {{- end }}
+    {{- end }}
     },
   {{- end }}
   } as const;
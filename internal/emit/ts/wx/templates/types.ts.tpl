@@ -21,3 +21,22 @@ export type {{ .Name }} = {{ .Alias }};
 
 {{- end }}
 {{- end }}
+{{- if .ErrorCodes }}
+
+// Machine-readable error codes from x-error-codes, sent in the "code" field
+// of error responses.
+export type ErrorCode =
+{{- range .ErrorCodes }}
+  | {{ printf "%q" .Code }}
+{{- end }};
+
+// The HTTP status each ErrorCode is sent with.
+export const ErrorStatus: Record<ErrorCode, number> = {
+{{- range .ErrorCodes }}
+  {{- if .Description }}
+  // {{ .Description }}
+  {{- end }}
+  {{ printf "%q" .Code }}: {{ .Status }},
+{{- end }}
+};
+{{- end }}
@@ -1,54 +1,79 @@
 package ir
 
+// The JSON tags below define the raw-ir document format (see json.go).
+// Changing a tag or the meaning of a field requires bumping FormatVersion.
+
 type Spec struct {
-	Meta   Meta
-	Types  map[string]TypeDecl
-	Routes []Route
+	Meta   Meta                `json:"meta"`
+	Types  map[string]TypeDecl `json:"types"`
+	Routes []Route             `json:"routes"`
 }
 
 type Meta struct {
-	BaseURL string
+	BaseURL string `json:"baseUrl"`
+
+	// ErrorCodes is the document-level x-error-codes catalog, in spec order.
+	ErrorCodes []ErrorCode `json:"errorCodes,omitempty"`
+}
+
+// ErrorCode is one entry of the x-error-codes catalog: a machine-readable
+// code carried in error responses and the HTTP status it is sent with.
+type ErrorCode struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description,omitempty"`
 }
 
 type Route struct {
-	Name   string
-	Tag    string
-	Method string
-	Path   string
+	Name   string `json:"name"` // operationId
+	Tag    string `json:"tag"`
+	Method string `json:"method"` // GET | POST
+	Path   string `json:"path"`
+
+	PathParams  []Param `json:"pathParams,omitempty"`
+	QueryParams []Param `json:"queryParams,omitempty"`
+
+	RequestBody *Body   `json:"requestBody,omitempty"`
+	Success     Success `json:"success"`
 
-	PathParams  []Param
-	QueryParams []Param
+	// Limits from x-timeout and x-max-body-bytes. The operation's value
+	// overrides the document's; zero means no limit. MaxBodyBytes is only
+	// set on routes with a RequestBody.
+	TimeoutMillis int64 `json:"timeoutMs,omitempty"`
+	MaxBodyBytes  int64 `json:"maxBodyBytes,omitempty"`
 
-	RequestBody *Body
-	Success     Success
+	// Extensions holds the operation's x-* fields as decoded JSON values.
+	Extensions map[string]any `json:"extensions,omitempty"`
 }
 
 type Param struct {
-	Name     string
-	Required bool
-	Type     TypeRef
+	Name     string  `json:"name"`
+	Required bool    `json:"required"`
+	Type     TypeRef `json:"type"`
 }
 
 type Body struct {
-	Required bool
-	Type     TypeRef
+	Required bool    `json:"required"`
+	Type     TypeRef `json:"type"`
 }
 
 type Success struct {
-	Status string
-	Type   *TypeRef
+	Status string   `json:"status"`
+	Type   *TypeRef `json:"type,omitempty"`
 }
 
 // ---- Types ----
 
 type TypeDecl struct {
-	Name string
-	Type Type
+	Name string `json:"name"`
+	Type Type   `json:"type"`
 }
 
+// TypeRef points at a named type in Spec.Types or carries an inline type.
+// Exactly one of RefName and Inline is set.
 type TypeRef struct {
-	RefName string
-	Inline  *Type
+	RefName string `json:"ref,omitempty"`
+	Inline  *Type  `json:"inline,omitempty"`
 }
 
 type TypeKind string
@@ -61,26 +86,26 @@ const (
 )
 
 type Type struct {
-	Kind TypeKind
+	Kind TypeKind `json:"kind"`
 
 	// scalar
-	Scalar string // "string" | "number" | "integer" | "boolean"
+	Scalar string `json:"scalar,omitempty"` // "string" | "number" | "integer" | "boolean"
 
 	// object
-	Fields []Field
+	Fields []Field `json:"fields,omitempty"`
 
 	// array
-	Elem *TypeRef
+	Elem *TypeRef `json:"elem,omitempty"`
 
 	// enum
-	Enum []string
+	Enum []string `json:"enum,omitempty"`
 
 	// nullable (OpenAPI 3)
-	Nullable bool
+	Nullable bool `json:"nullable,omitempty"`
 }
 
 type Field struct {
-	Name     string
-	Required bool
-	Type     TypeRef
+	Name     string  `json:"name"`
+	Required bool    `json:"required"`
+	Type     TypeRef `json:"type"`
 }
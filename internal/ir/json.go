@@ -0,0 +1,53 @@
+package ir
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FormatVersion is the version of the raw-ir JSON document. It is bumped on
+// any incompatible change to the serialized shape of Spec.
+const FormatVersion = 1
+
+// Document is the raw-ir JSON envelope: the version followed by the Spec
+// fields ({"version":1,"meta":{...},"types":{...},"routes":[...]}).
+type Document struct {
+	Version int `json:"version"`
+	*Spec
+}
+
+// Marshal serializes spec as an indented raw-ir document. Output is
+// deterministic: map keys are sorted by encoding/json and routes are already
+// ordered by normalize.
+func Marshal(spec *Spec) ([]byte, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("nil IR spec")
+	}
+	data, err := json.MarshalIndent(Document{Version: FormatVersion, Spec: spec}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal IR: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Unmarshal parses a raw-ir document, rejecting versions it does not understand.
+func Unmarshal(data []byte) (*Spec, error) {
+	var head struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("parse IR: %w", err)
+	}
+	if head.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported IR version %d (expected %d)", head.Version, FormatVersion)
+	}
+
+	doc := Document{Spec: &Spec{}}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse IR: %w", err)
+	}
+	if doc.Types == nil {
+		doc.Types = map[string]TypeDecl{}
+	}
+	return doc.Spec, nil
+}
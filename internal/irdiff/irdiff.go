@@ -0,0 +1,399 @@
+// Package irdiff compares two IR revisions and classifies every change by its
+// effect on clients built from the old revision.
+//
+// Types are compared where routes use them: a type reached from a parameter or
+// request body is judged as input (old clients keep sending the old shape),
+// one reached from a success response as output (old clients must still
+// understand what the new server sends). The same edit can therefore be
+// breaking in one direction and harmless in the other, e.g. adding an enum
+// value breaks responses but not requests.
+package irdiff
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+// Change codes.
+const (
+	OperationAdded      = "operation-added"
+	OperationRemoved    = "operation-removed"
+	OperationRenamed    = "operation-renamed"
+	OperationMoved      = "operation-moved"
+	OperationTagChanged = "operation-tag-changed"
+	BaseURLChanged      = "base-url-changed"
+
+	ParamAdded    = "param-added"
+	ParamRemoved  = "param-removed"
+	ParamRequired = "param-required"
+	ParamOptional = "param-optional"
+
+	BodyAdded    = "body-added"
+	BodyRemoved  = "body-removed"
+	BodyRequired = "body-required"
+	BodyOptional = "body-optional"
+
+	ResponseAdded   = "response-added"
+	ResponseRemoved = "response-removed"
+	StatusChanged   = "status-changed"
+
+	FieldAdded    = "field-added"
+	FieldRemoved  = "field-removed"
+	FieldRequired = "field-required"
+	FieldOptional = "field-optional"
+
+	TypeChanged      = "type-changed"
+	TypeRefChanged   = "type-ref-changed"
+	TypeWidened      = "type-widened"
+	TypeNarrowed     = "type-narrowed"
+	NullableAdded    = "nullable-added"
+	NullableRemoved  = "nullable-removed"
+	EnumValueAdded   = "enum-value-added"
+	EnumValueRemoved = "enum-value-removed"
+)
+
+type Change struct {
+	Code     string `json:"code"`
+	Breaking bool   `json:"breaking"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+func (c Change) String() string {
+	kind := "non-breaking"
+	if c.Breaking {
+		kind = "BREAKING"
+	}
+	return fmt.Sprintf("%-12s %s: %s [%s]", kind, c.Location, c.Message, c.Code)
+}
+
+// Bump is a suggested semantic version increment.
+type Bump string
+
+const (
+	BumpNone  Bump = "none"
+	BumpMinor Bump = "minor"
+	BumpMajor Bump = "major"
+)
+
+type Report struct {
+	Changes []Change `json:"changes"`
+	Bump    Bump     `json:"bump"`
+}
+
+// Breaking returns the breaking changes.
+func (r *Report) Breaking() []Change {
+	var out []Change
+	for _, c := range r.Changes {
+		if c.Breaking {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Summary is a one-line count with the suggested bump.
+func (r *Report) Summary() string {
+	if len(r.Changes) == 0 {
+		return "no API changes; suggested version bump: none"
+	}
+	b := len(r.Breaking())
+	return fmt.Sprintf("%d breaking, %d non-breaking change(s); suggested version bump: %s", b, len(r.Changes)-b, r.Bump)
+}
+
+type direction int
+
+const (
+	input  direction = iota // sent by clients
+	output                  // sent by the server
+)
+
+type comparer struct {
+	old, new *ir.Spec
+	changes  map[string]Change // code+location -> change, keeps the breaking one
+	visited  map[string]bool
+}
+
+// Compare reports the changes from old to new. Changes are de-duplicated per
+// location, so a named type used by several routes is reported once.
+func Compare(old, new *ir.Spec) *Report {
+	c := &comparer{old: old, new: new, changes: map[string]Change{}, visited: map[string]bool{}}
+
+	if old.Meta.BaseURL != new.Meta.BaseURL {
+		c.add(BaseURLChanged, false, "meta", "base URL changed from %q to %q", old.Meta.BaseURL, new.Meta.BaseURL)
+	}
+
+	newByName := map[string]ir.Route{}
+	newByEndpoint := map[string]ir.Route{}
+	for _, r := range new.Routes {
+		newByName[r.Name] = r
+		newByEndpoint[endpoint(r)] = r
+	}
+	matched := map[string]bool{}
+	for _, or := range old.Routes {
+		nr, ok := newByName[or.Name]
+		if !ok {
+			if nr, ok = newByEndpoint[endpoint(or)]; ok && !routeExists(old, nr.Name) {
+				c.add(OperationRenamed, false, routeLoc(or), "operationId renamed to %s (generated method names change)", nr.Name)
+			} else {
+				c.add(OperationRemoved, true, routeLoc(or), "operation removed")
+				continue
+			}
+		}
+		matched[nr.Name] = true
+		c.compareRoute(or, nr)
+	}
+	for _, nr := range new.Routes {
+		if !matched[nr.Name] {
+			c.add(OperationAdded, false, routeLoc(nr), "operation added")
+		}
+	}
+
+	rep := &Report{Changes: make([]Change, 0, len(c.changes)), Bump: BumpNone}
+	for _, ch := range c.changes {
+		rep.Changes = append(rep.Changes, ch)
+	}
+	sort.Slice(rep.Changes, func(i, j int) bool {
+		a, b := rep.Changes[i], rep.Changes[j]
+		if a.Breaking != b.Breaking {
+			return a.Breaking
+		}
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+		return a.Code < b.Code
+	})
+	if len(rep.Changes) > 0 {
+		rep.Bump = BumpMinor
+	}
+	if len(rep.Breaking()) > 0 {
+		rep.Bump = BumpMajor
+	}
+	return rep
+}
+
+func (c *comparer) add(code string, breaking bool, loc, format string, args ...any) {
+	key := code + "\x00" + loc
+	if prev, ok := c.changes[key]; ok && (prev.Breaking || !breaking) {
+		return
+	}
+	c.changes[key] = Change{Code: code, Breaking: breaking, Location: loc, Message: fmt.Sprintf(format, args...)}
+}
+
+func (c *comparer) compareRoute(or, nr ir.Route) {
+	loc := routeLoc(nr)
+	if or.Method != nr.Method || or.Path != nr.Path {
+		c.add(OperationMoved, true, loc, "endpoint changed from %s", endpoint(or))
+	}
+	if or.Tag != nr.Tag {
+		c.add(OperationTagChanged, false, loc, "tag changed from %q to %q (generated service grouping changes)", or.Tag, nr.Tag)
+	}
+
+	c.compareParams(loc+" path param", or.PathParams, nr.PathParams)
+	c.compareParams(loc+" query param", or.QueryParams, nr.QueryParams)
+
+	switch ob, nb := or.RequestBody, nr.RequestBody; {
+	case ob == nil && nb != nil:
+		c.add(BodyAdded, nb.Required, loc+" request body", "request body added (required: %t)", nb.Required)
+	case ob != nil && nb == nil:
+		c.add(BodyRemoved, false, loc+" request body", "request body removed")
+	case ob != nil && nb != nil:
+		if !ob.Required && nb.Required {
+			c.add(BodyRequired, true, loc+" request body", "request body is now required")
+		}
+		if ob.Required && !nb.Required {
+			c.add(BodyOptional, false, loc+" request body", "request body is now optional")
+		}
+		c.compareRef(loc+" request body", ob.Type, nb.Type, input)
+	}
+
+	if or.Success.Status != nr.Success.Status {
+		c.add(StatusChanged, true, loc+" response", "success status changed from %s to %s", or.Success.Status, nr.Success.Status)
+	}
+	switch ot, nt := or.Success.Type, nr.Success.Type; {
+	case ot == nil && nt != nil:
+		c.add(ResponseAdded, false, loc+" response", "response body added")
+	case ot != nil && nt == nil:
+		c.add(ResponseRemoved, true, loc+" response", "response body removed")
+	case ot != nil && nt != nil:
+		c.compareRef(loc+" response", *ot, *nt, output)
+	}
+}
+
+func (c *comparer) compareParams(loc string, old, new []ir.Param) {
+	oldByName := map[string]ir.Param{}
+	for _, p := range old {
+		oldByName[p.Name] = p
+	}
+	seen := map[string]bool{}
+	for _, np := range new {
+		seen[np.Name] = true
+		pl := loc + " " + np.Name
+		op, ok := oldByName[np.Name]
+		if !ok {
+			c.add(ParamAdded, np.Required, pl, "parameter added (required: %t)", np.Required)
+			continue
+		}
+		if !op.Required && np.Required {
+			c.add(ParamRequired, true, pl, "parameter is now required")
+		}
+		if op.Required && !np.Required {
+			c.add(ParamOptional, false, pl, "parameter is now optional")
+		}
+		c.compareRef(pl, op.Type, np.Type, input)
+	}
+	for _, op := range old {
+		if !seen[op.Name] {
+			c.add(ParamRemoved, false, loc+" "+op.Name, "parameter removed (old clients still send it)")
+		}
+	}
+}
+
+// compareRef compares two type references. Named types are reported at their
+// own name so that every route using them shares the same location.
+func (c *comparer) compareRef(loc string, old, new ir.TypeRef, dir direction) {
+	if old.RefName != "" && new.RefName != "" {
+		if old.RefName != new.RefName {
+			c.add(TypeRefChanged, false, loc, "type changed from %s to %s", old.RefName, new.RefName)
+		} else {
+			loc = new.RefName
+		}
+		key := fmt.Sprintf("%s\x00%s\x00%d", old.RefName, new.RefName, dir)
+		if c.visited[key] {
+			return
+		}
+		c.visited[key] = true
+	}
+
+	ot, ok1 := resolve(c.old, old)
+	nt, ok2 := resolve(c.new, new)
+	if !ok1 || !ok2 {
+		// dangling refs: normalize doesn't produce them, compare names only
+		if old.RefName != new.RefName {
+			c.add(TypeChanged, true, loc, "type changed")
+		}
+		return
+	}
+	c.compareType(loc, ot, nt, dir)
+}
+
+func (c *comparer) compareType(loc string, ot, nt ir.Type, dir direction) {
+	switch {
+	case !ot.Nullable && nt.Nullable:
+		c.add(NullableAdded, dir == output, loc, "is now nullable")
+	case ot.Nullable && !nt.Nullable:
+		c.add(NullableRemoved, dir == input, loc, "is no longer nullable")
+	}
+
+	if ot.Kind != nt.Kind {
+		switch {
+		case ot.Kind == ir.KindEnum && nt.Kind == ir.KindScalar && nt.Scalar == "string":
+			c.add(TypeWidened, dir == output, loc, "enum replaced by string")
+		case ot.Kind == ir.KindScalar && ot.Scalar == "string" && nt.Kind == ir.KindEnum:
+			c.add(TypeNarrowed, dir == input, loc, "string replaced by enum %s", strings.Join(nt.Enum, "|"))
+		default:
+			c.add(TypeChanged, true, loc, "type changed from %s to %s", describe(ot), describe(nt))
+		}
+		return
+	}
+
+	switch ot.Kind {
+	case ir.KindScalar:
+		switch {
+		case ot.Scalar == nt.Scalar:
+		case ot.Scalar == "integer" && nt.Scalar == "number":
+			c.add(TypeWidened, dir == output, loc, "type widened from integer to number")
+		case ot.Scalar == "number" && nt.Scalar == "integer":
+			c.add(TypeNarrowed, dir == input, loc, "type narrowed from number to integer")
+		default:
+			c.add(TypeChanged, true, loc, "type changed from %s to %s", ot.Scalar, nt.Scalar)
+		}
+
+	case ir.KindEnum:
+		var added, removed []string
+		for _, v := range nt.Enum {
+			if !slices.Contains(ot.Enum, v) {
+				added = append(added, v)
+			}
+		}
+		for _, v := range ot.Enum {
+			if !slices.Contains(nt.Enum, v) {
+				removed = append(removed, v)
+			}
+		}
+		if len(added) > 0 {
+			c.add(EnumValueAdded, dir == output, loc, "enum value(s) added: %s", strings.Join(added, ", "))
+		}
+		if len(removed) > 0 {
+			c.add(EnumValueRemoved, dir == input, loc, "enum value(s) removed: %s", strings.Join(removed, ", "))
+		}
+
+	case ir.KindArray:
+		if ot.Elem != nil && nt.Elem != nil {
+			c.compareRef(loc+"[]", *ot.Elem, *nt.Elem, dir)
+		}
+
+	case ir.KindObject:
+		oldFields := map[string]ir.Field{}
+		for _, f := range ot.Fields {
+			oldFields[f.Name] = f
+		}
+		seen := map[string]bool{}
+		for _, nf := range nt.Fields {
+			seen[nf.Name] = true
+			fl := loc + "." + nf.Name
+			of, ok := oldFields[nf.Name]
+			if !ok {
+				// old clients don't send it
+				c.add(FieldAdded, dir == input && nf.Required, fl, "field added (required: %t)", nf.Required)
+				continue
+			}
+			if !of.Required && nf.Required {
+				c.add(FieldRequired, dir == input, fl, "field is now required")
+			}
+			if of.Required && !nf.Required {
+				c.add(FieldOptional, dir == output, fl, "field is now optional")
+			}
+			c.compareRef(fl, of.Type, nf.Type, dir)
+		}
+		for _, of := range ot.Fields {
+			if !seen[of.Name] {
+				// old clients still send it and go-server rejects unknown
+				// fields; in responses they may rely on a required one
+				c.add(FieldRemoved, dir == input || of.Required, loc+"."+of.Name, "field removed")
+			}
+		}
+	}
+}
+
+func resolve(spec *ir.Spec, tr ir.TypeRef) (ir.Type, bool) {
+	if tr.Inline != nil {
+		return *tr.Inline, true
+	}
+	td, ok := spec.Types[tr.RefName]
+	return td.Type, ok
+}
+
+func describe(t ir.Type) string {
+	if t.Kind == ir.KindScalar {
+		return t.Scalar
+	}
+	return string(t.Kind)
+}
+
+func endpoint(r ir.Route) string { return r.Method + " " + r.Path }
+
+func routeLoc(r ir.Route) string { return r.Name + " (" + endpoint(r) + ")" }
+
+func routeExists(spec *ir.Spec, name string) bool {
+	for _, r := range spec.Routes {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package irdiff
+
+import (
+	"testing"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+func scalar(s string) ir.Type { return ir.Type{Kind: ir.KindScalar, Scalar: s} }
+
+func enum(vals ...string) ir.Type { return ir.Type{Kind: ir.KindEnum, Enum: vals} }
+
+func field(name string, required bool, t ir.Type) ir.Field {
+	return ir.Field{Name: name, Required: required, Type: ir.TypeRef{Inline: &t}}
+}
+
+func object(fields ...ir.Field) ir.Type { return ir.Type{Kind: ir.KindObject, Fields: fields} }
+
+// specWith uses t as the request body (In) and the response (Out) of one
+// route, so the same edit is judged in both directions.
+func specWith(in, out ir.Type) *ir.Spec {
+	return &ir.Spec{
+		Types: map[string]ir.TypeDecl{
+			"In":  {Name: "In", Type: in},
+			"Out": {Name: "Out", Type: out},
+		},
+		Routes: []ir.Route{{
+			Name:        "op",
+			Tag:         "T",
+			Method:      "POST",
+			Path:        "/op",
+			RequestBody: &ir.Body{Required: true, Type: ir.TypeRef{RefName: "In"}},
+			Success:     ir.Success{Status: "200", Type: &ir.TypeRef{RefName: "Out"}},
+		}},
+	}
+}
+
+func findChange(t *testing.T, rep *Report, code string) Change {
+	t.Helper()
+	for _, c := range rep.Changes {
+		if c.Code == code {
+			return c
+		}
+	}
+	t.Fatalf("no %s change in %v", code, rep.Changes)
+	return Change{}
+}
+
+func TestCompareTypeDirections(t *testing.T) {
+	cases := []struct {
+		name          string
+		code          string
+		old, new      ir.Type
+		input, output bool // expected Breaking per direction
+	}{
+		{"required field added", FieldAdded,
+			object(), object(field("a", true, scalar("string"))), true, false},
+		{"optional field added", FieldAdded,
+			object(), object(field("a", false, scalar("string"))), false, false},
+		{"optional field removed", FieldRemoved,
+			object(field("a", false, scalar("string"))), object(), true, false},
+		{"required field removed", FieldRemoved,
+			object(field("a", true, scalar("string"))), object(), true, true},
+		{"field now required", FieldRequired,
+			object(field("a", false, scalar("string"))), object(field("a", true, scalar("string"))), true, false},
+		{"field now optional", FieldOptional,
+			object(field("a", true, scalar("string"))), object(field("a", false, scalar("string"))), false, true},
+		{"scalar changed", TypeChanged,
+			object(field("a", true, scalar("string"))), object(field("a", true, scalar("boolean"))), true, true},
+		{"integer widened", TypeWidened,
+			object(field("a", true, scalar("integer"))), object(field("a", true, scalar("number"))), false, true},
+		{"enum widened", TypeWidened,
+			object(field("a", true, enum("x"))), object(field("a", true, scalar("string"))), false, true},
+		{"number narrowed", TypeNarrowed,
+			object(field("a", true, scalar("number"))), object(field("a", true, scalar("integer"))), true, false},
+		{"string narrowed", TypeNarrowed,
+			object(field("a", true, scalar("string"))), object(field("a", true, enum("x"))), true, false},
+		{"nullable added", NullableAdded,
+			object(), ir.Type{Kind: ir.KindObject, Nullable: true}, false, true},
+		{"nullable removed", NullableRemoved,
+			ir.Type{Kind: ir.KindObject, Nullable: true}, object(), true, false},
+		{"enum value added", EnumValueAdded,
+			object(field("a", true, enum("x"))), object(field("a", true, enum("x", "y"))), false, true},
+		{"enum value removed", EnumValueRemoved,
+			object(field("a", true, enum("x", "y"))), object(field("a", true, enum("x"))), true, false},
+	}
+	empty := object()
+	for _, tc := range cases {
+		t.Run(tc.name+"/input", func(t *testing.T) {
+			rep := Compare(specWith(tc.old, empty), specWith(tc.new, empty))
+			if got := findChange(t, rep, tc.code).Breaking; got != tc.input {
+				t.Errorf("breaking = %t, want %t", got, tc.input)
+			}
+		})
+		t.Run(tc.name+"/output", func(t *testing.T) {
+			rep := Compare(specWith(empty, tc.old), specWith(empty, tc.new))
+			if got := findChange(t, rep, tc.code).Breaking; got != tc.output {
+				t.Errorf("breaking = %t, want %t", got, tc.output)
+			}
+		})
+	}
+}
+
+func TestCompareRoutes(t *testing.T) {
+	base := func() *ir.Spec { return specWith(object(), object()) }
+	str := scalar("string")
+	param := func(name string, required bool) ir.Param {
+		return ir.Param{Name: name, Required: required, Type: ir.TypeRef{Inline: &str}}
+	}
+	cases := []struct {
+		name     string
+		code     string
+		edit     func(old, new *ir.Spec)
+		breaking bool
+	}{
+		{"operation added", OperationAdded, func(_, n *ir.Spec) {
+			r := n.Routes[0]
+			r.Name, r.Path = "op2", "/op2"
+			n.Routes = append(n.Routes, r)
+		}, false},
+		{"operation removed", OperationRemoved, func(_, n *ir.Spec) { n.Routes = nil }, true},
+		{"operation renamed", OperationRenamed, func(_, n *ir.Spec) { n.Routes[0].Name = "op2" }, false},
+		{"operation moved", OperationMoved, func(_, n *ir.Spec) { n.Routes[0].Path = "/moved" }, true},
+		{"tag changed", OperationTagChanged, func(_, n *ir.Spec) { n.Routes[0].Tag = "U" }, false},
+		{"base URL changed", BaseURLChanged, func(_, n *ir.Spec) { n.Meta.BaseURL = "https://b" }, false},
+		{"required param added", ParamAdded, func(_, n *ir.Spec) {
+			n.Routes[0].QueryParams = []ir.Param{param("q", true)}
+		}, true},
+		{"optional param added", ParamAdded, func(_, n *ir.Spec) {
+			n.Routes[0].QueryParams = []ir.Param{param("q", false)}
+		}, false},
+		{"param removed", ParamRemoved, func(o, _ *ir.Spec) {
+			o.Routes[0].QueryParams = []ir.Param{param("q", true)}
+		}, false},
+		{"param now required", ParamRequired, func(o, n *ir.Spec) {
+			o.Routes[0].QueryParams = []ir.Param{param("q", false)}
+			n.Routes[0].QueryParams = []ir.Param{param("q", true)}
+		}, true},
+		{"param now optional", ParamOptional, func(o, n *ir.Spec) {
+			o.Routes[0].QueryParams = []ir.Param{param("q", true)}
+			n.Routes[0].QueryParams = []ir.Param{param("q", false)}
+		}, false},
+		{"required body added", BodyAdded, func(o, _ *ir.Spec) { o.Routes[0].RequestBody = nil }, true},
+		{"body removed", BodyRemoved, func(_, n *ir.Spec) { n.Routes[0].RequestBody = nil }, false},
+		{"body now required", BodyRequired, func(o, _ *ir.Spec) { o.Routes[0].RequestBody.Required = false }, true},
+		{"body now optional", BodyOptional, func(_, n *ir.Spec) { n.Routes[0].RequestBody.Required = false }, false},
+		{"response added", ResponseAdded, func(o, _ *ir.Spec) { o.Routes[0].Success.Type = nil }, false},
+		{"response removed", ResponseRemoved, func(_, n *ir.Spec) { n.Routes[0].Success.Type = nil }, true},
+		{"status changed", StatusChanged, func(_, n *ir.Spec) { n.Routes[0].Success.Status = "201" }, true},
+		{"type ref changed", TypeRefChanged, func(_, n *ir.Spec) {
+			n.Types["In2"] = ir.TypeDecl{Name: "In2", Type: object()}
+			n.Routes[0].RequestBody.Type = ir.TypeRef{RefName: "In2"}
+		}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			old, new := base(), base()
+			tc.edit(old, new)
+			rep := Compare(old, new)
+			if got := findChange(t, rep, tc.code).Breaking; got != tc.breaking {
+				t.Errorf("breaking = %t, want %t", got, tc.breaking)
+			}
+		})
+	}
+}
+
+func TestCompareBump(t *testing.T) {
+	in := object(field("nick", false, scalar("string")))
+	rep := Compare(specWith(in, object()), specWith(object(), object()))
+	if rep.Bump != BumpMajor {
+		t.Errorf("removing a request field: bump = %s, want %s", rep.Bump, BumpMajor)
+	}
+	if rep := Compare(specWith(object(), object()), specWith(object(), object())); rep.Bump != BumpNone || len(rep.Changes) != 0 {
+		t.Errorf("identical specs: %v", rep)
+	}
+}
@@ -1,22 +1,19 @@
 package normalize
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
-func collectComponentSchemas(doc *openapi3.T) (map[string]ir.TypeDecl, error) {
+func (n *normalizer) collectComponentSchemas(doc *openapi3.T) map[string]ir.TypeDecl {
 	out := map[string]ir.TypeDecl{}
 
-	if doc == nil {
-		return nil, fmt.Errorf("nil OpenAPI doc")
-	}
-	if doc.Components.Schemas == nil {
-		return out, nil // allowed: empty
+	if doc.Components == nil || doc.Components.Schemas == nil {
+		return out // allowed: empty
 	}
 
 	// deterministic iteration
@@ -26,36 +23,34 @@ func collectComponentSchemas(doc *openapi3.T) (map[string]ir.TypeDecl, error) {
 	}
 	sort.Strings(names)
 
-	for _, name := range names {
-		name = strings.TrimSpace(name)
+	for _, rawName := range names {
+		ptr := diag.Pointer("", "components", "schemas", rawName)
+		name := strings.TrimSpace(rawName)
 		if name == "" {
-			return nil, fmt.Errorf("components.schemas contains empty name")
+			n.diags.Reportf(diag.RuleComponentSchema, ptr, "components.schemas contains empty name")
+			continue
 		}
 
-		sr := doc.Components.Schemas[name]
+		sr := doc.Components.Schemas[rawName]
 		if sr == nil {
-			return nil, fmt.Errorf("components.schemas.%s is nil", name)
+			n.diags.Reportf(diag.RuleComponentSchema, ptr, "components.schemas.%s is nil", name)
+			continue
 		}
 
-		// accept either inline schema or $ref, but in practice components.schemas should be inline.
-		// if it's a $ref, resolve it through SchemaRefToTypeRef.
+		// keep it strict: components.schemas entries must be inline (Value != nil).
 		if sr.Ref != "" {
-			_, err := SchemaRefToTypeRef(sr)
-			if err != nil {
-				return nil, fmt.Errorf("components.schemas.%s: %w", name, err)
-			}
-			// keep it strict: components.schemas entries must be inline (Value != nil).
-			return nil, fmt.Errorf("components.schemas.%s: $ref schema entries are not supported; define schema inline", name)
-
+			n.diags.Reportf(diag.RuleComponentSchema, ptr, "components.schemas.%s: $ref schema entries are not supported; define schema inline", name)
+			continue
 		}
 
 		if sr.Value == nil {
-			return nil, fmt.Errorf("components.schemas.%s has no schema value", name)
+			n.diags.Reportf(diag.RuleComponentSchema, ptr, "components.schemas.%s has no schema value", name)
+			continue
 		}
 
-		t, err := schemaValueToType(sr.Value)
-		if err != nil {
-			return nil, fmt.Errorf("components.schemas.%s: %w", name, err)
+		t, ok := n.schemaValueToType(sr.Value, ptr)
+		if !ok {
+			continue
 		}
 
 		out[name] = ir.TypeDecl{
@@ -64,5 +59,5 @@ func collectComponentSchemas(doc *openapi3.T) (map[string]ir.TypeDecl, error) {
 		}
 	}
 
-	return out, nil
+	return out
 }
@@ -0,0 +1,100 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+// errorCodeRe keeps codes usable as TS string literals and, once camel-cased,
+// as Go identifiers: "USER_NOT_FOUND", "user.not_found".
+var errorCodeRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// collectErrorCodes reads the document-level x-error-codes catalog:
+//
+//	x-error-codes:
+//	  - code: USER_NOT_FOUND
+//	    status: 404
+//	    description: the user does not exist
+func (n *normalizer) collectErrorCodes(doc *openapi3.T) []ir.ErrorCode {
+	raw, ok := doc.Extensions["x-error-codes"]
+	if !ok {
+		return nil
+	}
+	ptr := diag.Pointer("", "x-error-codes")
+	list, ok := raw.([]any)
+	if !ok {
+		n.diags.Reportf(diag.RuleErrorCodes, ptr, "x-error-codes must be a list of {code, status, description}")
+		return nil
+	}
+
+	var out []ir.ErrorCode
+	seen := map[string]bool{}
+	for i, item := range list {
+		itemPtr := diag.Pointer(ptr, strconv.Itoa(i))
+		m, ok := item.(map[string]any)
+		if !ok {
+			n.diags.Reportf(diag.RuleErrorCodes, itemPtr, "x-error-codes[%d] must be an object", i)
+			continue
+		}
+		for k := range m {
+			if k != "code" && k != "status" && k != "description" {
+				n.diags.Reportf(diag.RuleErrorCodes, diag.Pointer(itemPtr, k), "x-error-codes[%d]: unknown field %q", i, k)
+			}
+		}
+
+		code, _ := m["code"].(string)
+		code = strings.TrimSpace(code)
+		if !errorCodeRe.MatchString(code) {
+			n.diags.Reportf(diag.RuleErrorCodes, diag.Pointer(itemPtr, "code"),
+				"x-error-codes[%d]: invalid code %q (must match %s)", i, code, errorCodeRe.String())
+			continue
+		}
+		if seen[code] {
+			n.diags.Reportf(diag.RuleErrorCodes, diag.Pointer(itemPtr, "code"), "x-error-codes: duplicate code %q", code)
+			continue
+		}
+		seen[code] = true
+
+		status, ok := intValue(m["status"])
+		if !ok || status < 400 || status > 599 {
+			n.diags.Reportf(diag.RuleErrorCodes, diag.Pointer(itemPtr, "status"),
+				"x-error-codes[%d]: %s: status must be an integer in 400-599, got %v", i, code, m["status"])
+			continue
+		}
+
+		var desc string
+		if d, ok := m["description"]; ok {
+			if desc, ok = d.(string); !ok {
+				n.diags.Reportf(diag.RuleErrorCodes, diag.Pointer(itemPtr, "description"),
+					"x-error-codes[%d]: %s: description must be a string", i, code)
+			}
+		}
+
+		out = append(out, ir.ErrorCode{Code: code, Status: status, Description: strings.TrimSpace(desc)})
+	}
+	return out
+}
+
+// intValue accepts the number types a decoded YAML or JSON extension may hold.
+func intValue(v any) (int, bool) {
+	switch x := v.(type) {
+	case int:
+		return x, true
+	case int64:
+		return int(x), true
+	case uint64:
+		return int(x), true
+	case float64:
+		if x != float64(int(x)) {
+			return 0, false
+		}
+		return int(x), true
+	default:
+		return 0, false
+	}
+}
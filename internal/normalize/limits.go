@@ -0,0 +1,48 @@
+package normalize
+
+import (
+	"time"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
+)
+
+// limits are the x-timeout and x-max-body-bytes values in effect for an
+// operation. Zero means no limit.
+type limits struct {
+	timeoutMillis int64
+	maxBodyBytes  int64
+}
+
+// readLimits applies the x-timeout and x-max-body-bytes entries of ext on top
+// of base. An explicit 0 (or "0s") removes a limit inherited from the
+// document.
+//
+//	x-timeout: 5s              # Go duration syntax, at least 1ms
+//	x-max-body-bytes: 1048576
+func (n *normalizer) readLimits(ext map[string]any, ptr string, base limits) limits {
+	out := base
+	if v, ok := ext["x-timeout"]; ok {
+		s, isStr := v.(string)
+		d, err := time.ParseDuration(s)
+		switch {
+		case !isStr || err != nil:
+			n.diags.Reportf(diag.RuleLimits, diag.Pointer(ptr, "x-timeout"),
+				"x-timeout: invalid duration %v (use Go duration syntax such as \"5s\" or \"500ms\")", v)
+		case d < 0 || (d > 0 && d < time.Millisecond):
+			n.diags.Reportf(diag.RuleLimits, diag.Pointer(ptr, "x-timeout"),
+				"x-timeout: %s must be 0 or at least 1ms", s)
+		default:
+			out.timeoutMillis = d.Milliseconds()
+		}
+	}
+	if v, ok := ext["x-max-body-bytes"]; ok {
+		b, isInt := intValue(v)
+		if !isInt || b < 0 {
+			n.diags.Reportf(diag.RuleLimits, diag.Pointer(ptr, "x-max-body-bytes"),
+				"x-max-body-bytes: must be a non-negative integer, got %v", v)
+		} else {
+			out.maxBodyBytes = int64(b)
+		}
+	}
+	return out
+}
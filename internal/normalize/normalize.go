@@ -7,40 +7,57 @@ import (
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
 type Options struct {
 	BaseURLOverride string
+
+	// Diagnostics receives every violation found during normalization.
+	// When nil, a collector with default rule severities is used.
+	Diagnostics *diag.Collector
 }
 
 var identRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
 
+type normalizer struct {
+	diags *diag.Collector
+
+	docLimits limits // document-level x-timeout / x-max-body-bytes
+}
+
+// ToIR normalizes doc in a single pass, reporting every violation instead of
+// stopping at the first one. It fails with a diag.List if any error was reported.
 func ToIR(doc *openapi3.T, opt Options) (*ir.Spec, error) {
 	if doc == nil {
 		return nil, fmt.Errorf("nil OpenAPI doc")
 	}
 
-	baseURL := strings.TrimSpace(doc.Servers[0].URL)
-	if opt.BaseURLOverride != "" {
-		baseURL = strings.TrimSpace(opt.BaseURLOverride)
+	n := &normalizer{diags: opt.Diagnostics}
+	if n.diags == nil {
+		n.diags = diag.NewCollector()
 	}
-	if baseURL == "" {
-		return nil, fmt.Errorf("baseURL is empty (servers[0].url or --base-url)")
+
+	// missing servers[0].url is reported by openapi.LoadAndValidate
+	baseURL := ""
+	if len(doc.Servers) > 0 && doc.Servers[0] != nil {
+		baseURL = strings.TrimSpace(doc.Servers[0].URL)
+	}
+	if override := strings.TrimSpace(opt.BaseURLOverride); override != "" {
+		baseURL = override
 	}
 
 	out := &ir.Spec{
-		Meta:   ir.Meta{BaseURL: baseURL},
+		Meta:   ir.Meta{BaseURL: baseURL, ErrorCodes: n.collectErrorCodes(doc)},
 		Types:  map[string]ir.TypeDecl{}, // filled later (optional)
 		Routes: []ir.Route{},
 	}
 
+	n.docLimits = n.readLimits(doc.Extensions, "", limits{})
+
 	// Collect component schemas as types
-	types, err := collectComponentSchemas(doc)
-	if err != nil {
-		return nil, err
-	}
-	out.Types = types
+	out.Types = n.collectComponentSchemas(doc)
 
 	// Enforce: every operation must have unique operationId
 	seenOpID := map[string]string{} // opId -> "METHOD path"
@@ -52,6 +69,7 @@ func ToIR(doc *openapi3.T, opt Options) (*ir.Spec, error) {
 		if item == nil {
 			continue
 		}
+		pathPtr := diag.Pointer("", "paths", p)
 
 		// Only support GET/POST
 		for _, m := range []string{"GET", "POST"} {
@@ -59,75 +77,22 @@ func ToIR(doc *openapi3.T, opt Options) (*ir.Spec, error) {
 			if op == nil {
 				continue
 			}
-
-			loc := fmt.Sprintf("%s %s", m, p)
-
-			// operationId must exist and be a legal identifier
-			opID := strings.TrimSpace(op.OperationID)
-			if opID == "" {
-				return nil, fmt.Errorf("%s: missing operationId (required)", loc)
-			}
-			if !identRe.MatchString(opID) {
-				return nil, fmt.Errorf("%s: invalid operationId %q (must match %s)", loc, opID, identRe.String())
-			}
-			if prev, ok := seenOpID[opID]; ok {
-				return nil, fmt.Errorf("%s: duplicate operationId %q (already used by %s)", loc, opID, prev)
-			}
-			seenOpID[opID] = loc
-
-			// tags[0] for grouping
-			tag := "Default"
-			if len(op.Tags) > 0 && strings.TrimSpace(op.Tags[0]) != "" {
-				tag = sanitizeIdent(op.Tags[0])
-				if tag == "" {
-					tag = "Default"
-				}
-			}
-
-			// GET must not have requestBody
-			if m == "GET" && op.RequestBody != nil {
-				return nil, fmt.Errorf("%s: requestBody is not allowed for GET", loc)
-			}
-
-			// parameters (path/query only)
-			pathParams, queryParams, err := collectParams(item, op)
-			if err != nil {
-				return nil, fmt.Errorf("%s: %w", loc, err)
-			}
-
-			// request body (POST only; optional)
-			var reqBody *ir.Body
-			if m == "POST" {
-				reqBody, err = normalizeRequestBody(op)
-				if err != nil {
-					return nil, fmt.Errorf("%s: %w", loc, err)
-				}
+			if route, ok := n.normalizeOperation(item, op, m, p, pathPtr, seenOpID); ok {
+				out.Routes = append(out.Routes, route)
 			}
-
-			// responses: must be only 200 + application/json + schema
-			success, err := normalizeSuccessResponse(op)
-			if err != nil {
-				return nil, fmt.Errorf("%s: %w", loc, err)
-			}
-
-			out.Routes = append(out.Routes, ir.Route{
-				Name:        opID,
-				Tag:         tag,
-				Method:      m,
-				Path:        p,
-				PathParams:  pathParams,
-				QueryParams: queryParams,
-				RequestBody: reqBody,
-				Success:     success,
-			})
 		}
 
 		// Reject other HTTP methods if present (strict)
-		if hasUnsupportedMethods(item) {
-			return nil, fmt.Errorf("%s: contains unsupported HTTP method (only GET/POST allowed)", p)
+		for _, m := range unsupportedMethods(item) {
+			n.diags.Reportf(diag.RuleMethodUnsupported, diag.Pointer(pathPtr, strings.ToLower(m)),
+				"%s %s: HTTP method %s is not supported (only GET/POST allowed)", m, p, m)
 		}
 	}
 
+	if err := n.diags.Err(); err != nil {
+		return nil, err
+	}
+
 	// stable order: by Tag then Name
 	sort.Slice(out.Routes, func(i, j int) bool {
 		if out.Routes[i].Tag != out.Routes[j].Tag {
@@ -139,6 +104,101 @@ func ToIR(doc *openapi3.T, opt Options) (*ir.Spec, error) {
 	return out, nil
 }
 
+func (n *normalizer) normalizeOperation(item *openapi3.PathItem, op *openapi3.Operation, m, p, pathPtr string, seenOpID map[string]string) (ir.Route, bool) {
+	loc := fmt.Sprintf("%s %s", m, p)
+	opPtr := diag.Pointer(pathPtr, strings.ToLower(m))
+	ok := true
+
+	// operationId must exist and be a legal identifier
+	opID := strings.TrimSpace(op.OperationID)
+	switch {
+	case opID == "":
+		n.diags.Reportf(diag.RuleOperationIDMissing, opPtr, "%s: missing operationId (required)", loc)
+		ok = false
+	case !identRe.MatchString(opID):
+		n.diags.Reportf(diag.RuleOperationIDInvalid, diag.Pointer(opPtr, "operationId"),
+			"%s: invalid operationId %q (must match %s)", loc, opID, identRe.String())
+		ok = false
+	default:
+		if prev, dup := seenOpID[opID]; dup {
+			n.diags.Reportf(diag.RuleOperationIDDuplicate, diag.Pointer(opPtr, "operationId"),
+				"%s: duplicate operationId %q (already used by %s)", loc, opID, prev)
+			ok = false
+		} else {
+			seenOpID[opID] = loc
+		}
+	}
+
+	// tags[0] for grouping
+	tag := "Default"
+	if len(op.Tags) > 0 && strings.TrimSpace(op.Tags[0]) != "" {
+		tag = sanitizeIdent(op.Tags[0])
+		if tag == "" {
+			tag = "Default"
+		}
+	} else {
+		n.diags.Reportf(diag.RuleOperationTagMissing, opPtr, "%s: no tags; grouped under %q", loc, tag)
+	}
+
+	// GET must not have requestBody
+	if m == "GET" && op.RequestBody != nil {
+		n.diags.Reportf(diag.RuleGetRequestBody, diag.Pointer(opPtr, "requestBody"), "%s: requestBody is not allowed for GET", loc)
+		ok = false
+	}
+
+	// parameters (path/query only)
+	pathParams, queryParams, paramsOK := n.collectParams(item, op, pathPtr, opPtr)
+	ok = ok && paramsOK
+
+	// request body (POST only; optional)
+	var reqBody *ir.Body
+	if m == "POST" {
+		var bodyOK bool
+		reqBody, bodyOK = n.normalizeRequestBody(op, diag.Pointer(opPtr, "requestBody"))
+		ok = ok && bodyOK
+	}
+
+	// responses: must be only 200 + application/json + schema
+	success, respOK := n.normalizeSuccessResponse(op, diag.Pointer(opPtr, "responses"))
+	ok = ok && respOK
+
+	lim := n.readLimits(op.Extensions, opPtr, n.docLimits)
+	if reqBody == nil {
+		lim.maxBodyBytes = 0 // nothing to limit
+	}
+
+	return ir.Route{
+		Name:        opID,
+		Tag:         tag,
+		Method:      m,
+		Path:        p,
+		PathParams:  pathParams,
+		QueryParams: queryParams,
+		RequestBody: reqBody,
+		Success:     success,
+
+		TimeoutMillis: lim.timeoutMillis,
+		MaxBodyBytes:  lim.maxBodyBytes,
+
+		Extensions: extensions(op.Extensions),
+	}, ok
+}
+
+// extensions keeps the x-* entries of ext; nil when there are none.
+func extensions(ext map[string]any) map[string]any {
+	var out map[string]any
+	for k, v := range ext {
+		if !strings.HasPrefix(k, "x-") {
+			continue
+		}
+		if out == nil {
+			out = map[string]any{}
+		}
+		out[k] = v
+	}
+	return out
+}
+
 func operationByMethod(item *openapi3.PathItem, method string) *openapi3.Operation {
 	switch method {
 	case "GET":
@@ -150,12 +210,15 @@ func operationByMethod(item *openapi3.PathItem, method string) *openapi3.Operati
 	}
 }
 
-func hasUnsupportedMethods(item *openapi3.PathItem) bool {
-	// Any method outside GET/POST triggers strict error if defined
-	if item.Put != nil || item.Delete != nil || item.Patch != nil || item.Options != nil || item.Head != nil || item.Trace != nil {
-		return true
+// unsupportedMethods lists methods outside GET/POST defined on item.
+func unsupportedMethods(item *openapi3.PathItem) []string {
+	var out []string
+	for _, m := range []string{"PUT", "DELETE", "PATCH", "OPTIONS", "HEAD", "TRACE"} {
+		if item.GetOperation(m) != nil {
+			out = append(out, m)
+		}
 	}
-	return false
+	return out
 }
 
 func sortedKeys[M ~map[string]V, V any](m M) []string {
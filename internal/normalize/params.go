@@ -1,43 +1,61 @@
 package normalize
 
 import (
-	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
-func collectParams(item *openapi3.PathItem, op *openapi3.Operation) ([]ir.Param, []ir.Param, error) {
-	merged := make([]*openapi3.ParameterRef, 0, len(item.Parameters)+len(op.Parameters))
-	merged = append(merged, item.Parameters...)
-	merged = append(merged, op.Parameters...)
+func (n *normalizer) collectParams(item *openapi3.PathItem, op *openapi3.Operation, pathPtr, opPtr string) ([]ir.Param, []ir.Param, bool) {
+	type paramAt struct {
+		ref *openapi3.ParameterRef
+		ptr string
+	}
+	merged := make([]paramAt, 0, len(item.Parameters)+len(op.Parameters))
+	for i, pr := range item.Parameters {
+		merged = append(merged, paramAt{pr, diag.Pointer(pathPtr, "parameters", strconv.Itoa(i))})
+	}
+	for i, pr := range op.Parameters {
+		merged = append(merged, paramAt{pr, diag.Pointer(opPtr, "parameters", strconv.Itoa(i))})
+	}
 
 	seen := map[string]struct{}{} // in:name
+	ok := true
 
 	var pathParams []ir.Param
 	var queryParams []ir.Param
 
-	for _, pr := range merged {
+	for _, pa := range merged {
+		pr, ptr := pa.ref, pa.ptr
 		if pr == nil || pr.Value == nil {
-			return nil, nil, fmt.Errorf("parameter is nil")
+			n.diags.Reportf(diag.RuleParameterInvalid, ptr, "parameter is nil")
+			ok = false
+			continue
 		}
 		p := pr.Value
 
 		in := strings.TrimSpace(p.In)
 		name := strings.TrimSpace(p.Name)
 		if in == "" || name == "" {
-			return nil, nil, fmt.Errorf("parameter has empty in/name")
+			n.diags.Reportf(diag.RuleParameterInvalid, ptr, "parameter has empty in/name")
+			ok = false
+			continue
 		}
 
 		// strict: only path/query
 		if in != "path" && in != "query" {
-			return nil, nil, fmt.Errorf("parameter %q in %q is not supported (only path/query)", name, in)
+			n.diags.Reportf(diag.RuleParameterLocation, diag.Pointer(ptr, "in"),
+				"parameter %q in %q is not supported (only path/query)", name, in)
+			ok = false
+			continue
 		}
 
 		key := in + ":" + name
-		if _, ok := seen[key]; ok {
+		if _, dup := seen[key]; dup {
 			// ignore duplicates deterministically (PathItem + Operation)
 			continue
 		}
@@ -50,12 +68,15 @@ func collectParams(item *openapi3.PathItem, op *openapi3.Operation) ([]ir.Param,
 		}
 
 		if p.Schema == nil {
-			return nil, nil, fmt.Errorf("parameter %q in %q must define schema", name, in)
+			n.diags.Reportf(diag.RuleParameterSchema, ptr, "parameter %q in %q must define schema", name, in)
+			ok = false
+			continue
 		}
 
-		typ, err := SchemaRefToTypeRef(p.Schema)
-		if err != nil {
-			return nil, nil, fmt.Errorf("parameter %q in %q: %w", name, in, err)
+		typ, typOK := n.schemaRefToTypeRef(p.Schema, diag.Pointer(ptr, "schema"))
+		if !typOK {
+			ok = false
+			continue
 		}
 
 		param := ir.Param{
@@ -75,5 +96,5 @@ func collectParams(item *openapi3.PathItem, op *openapi3.Operation) ([]ir.Param,
 	sort.Slice(pathParams, func(i, j int) bool { return pathParams[i].Name < pathParams[j].Name })
 	sort.Slice(queryParams, func(i, j int) bool { return queryParams[i].Name < queryParams[j].Name })
 
-	return pathParams, queryParams, nil
+	return pathParams, queryParams, ok
 }
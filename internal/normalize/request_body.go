@@ -1,16 +1,16 @@
 package normalize
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
-func normalizeRequestBody(op *openapi3.Operation) (*ir.Body, error) {
+func (n *normalizer) normalizeRequestBody(op *openapi3.Operation, ptr string) (*ir.Body, bool) {
 	if op.RequestBody == nil || op.RequestBody.Value == nil {
-		return nil, nil // body optional for POST
+		return nil, true // body optional for POST
 	}
 
 	mt := "application/json"
@@ -18,31 +18,35 @@ func normalizeRequestBody(op *openapi3.Operation) (*ir.Body, error) {
 	if rb.Content == nil || rb.Content[mt] == nil {
 		alt, schema := findJSONContent(rb.Content)
 		if schema == nil {
-			return nil, fmt.Errorf("requestBody must have %q content", mt)
+			n.diags.Reportf(diag.RuleRequestBodyContent, diag.Pointer(ptr, "content"), "requestBody must have %q content", mt)
+			return nil, false
 		}
-		typ, err := SchemaRefToTypeRef(schema)
-		if err != nil {
-			return nil, fmt.Errorf("requestBody %q schema: %w", alt, err)
+		typ, ok := n.schemaRefToTypeRef(schema, diag.Pointer(ptr, "content", alt, "schema"))
+		if !ok {
+			return nil, false
 		}
-		return &ir.Body{Required: rb.Required, Type: typ}, nil
+		return &ir.Body{Required: rb.Required, Type: typ}, true
 	}
 
+	mtPtr := diag.Pointer(ptr, "content", mt)
 	if rb.Content[mt].Schema == nil {
-		return nil, fmt.Errorf("requestBody %q must define schema", mt)
+		n.diags.Reportf(diag.RuleRequestBodyContent, mtPtr, "requestBody %q must define schema", mt)
+		return nil, false
 	}
 
-	typ, err := SchemaRefToTypeRef(rb.Content[mt].Schema)
-	if err != nil {
-		return nil, fmt.Errorf("requestBody %q schema: %w", mt, err)
+	typ, ok := n.schemaRefToTypeRef(rb.Content[mt].Schema, diag.Pointer(mtPtr, "schema"))
+	if !ok {
+		return nil, false
 	}
 
 	// Optional sanity: reject empty schema
 	if strings.TrimSpace(typ.RefName) == "" && typ.Inline == nil {
-		return nil, fmt.Errorf("requestBody schema is empty/unsupported")
+		n.diags.Reportf(diag.RuleRequestBodyContent, diag.Pointer(mtPtr, "schema"), "requestBody schema is empty/unsupported")
+		return nil, false
 	}
 
 	return &ir.Body{
 		Required: rb.Required,
 		Type:     typ,
-	}, nil
+	}, true
 }
@@ -1,17 +1,18 @@
 package normalize
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
-func normalizeSuccessResponse(op *openapi3.Operation) (ir.Success, error) {
+func (n *normalizer) normalizeSuccessResponse(op *openapi3.Operation, ptr string) (ir.Success, bool) {
 	if op.Responses == nil {
-		return ir.Success{}, fmt.Errorf("responses is missing")
+		n.diags.Reportf(diag.RuleResponseStatus, ptr, "responses is missing")
+		return ir.Success{}, false
 	}
 
 	// Strict: only "200" is allowed
@@ -22,15 +23,19 @@ func normalizeSuccessResponse(op *openapi3.Operation) (ir.Success, error) {
 	sort.Strings(keys)
 
 	if len(keys) == 0 {
-		return ir.Success{}, fmt.Errorf("responses is empty; must define 200")
+		n.diags.Reportf(diag.RuleResponseStatus, ptr, "responses is empty; must define 200")
+		return ir.Success{}, false
 	}
 	if len(keys) != 1 || keys[0] != "200" {
-		return ir.Success{}, fmt.Errorf("only responses[\"200\"] is allowed; found: %v", keys)
+		n.diags.Reportf(diag.RuleResponseStatus, ptr, "only responses[\"200\"] is allowed; found: %v", keys)
+		return ir.Success{}, false
 	}
 
+	r200Ptr := diag.Pointer(ptr, "200")
 	r200 := op.Responses.Map()["200"]
 	if r200 == nil || r200.Value == nil {
-		return ir.Success{}, fmt.Errorf("responses[\"200\"] is nil")
+		n.diags.Reportf(diag.RuleResponseContent, r200Ptr, "responses[\"200\"] is nil")
+		return ir.Success{}, false
 	}
 
 	mt := "application/json"
@@ -39,28 +44,31 @@ func normalizeSuccessResponse(op *openapi3.Operation) (ir.Success, error) {
 		// also allow "application/json; charset=utf-8"? (some specs do)
 		alt, schema := findJSONContent(content)
 		if schema == nil {
-			return ir.Success{}, fmt.Errorf("responses[\"200\"] must have %q content", mt)
+			n.diags.Reportf(diag.RuleResponseContent, r200Ptr, "responses[\"200\"] must have %q content", mt)
+			return ir.Success{}, false
 		}
-		typ, err := SchemaRefToTypeRef(schema)
-		if err != nil {
-			return ir.Success{}, fmt.Errorf("responses[\"200\"] %q schema: %w", alt, err)
+		typ, ok := n.schemaRefToTypeRef(schema, diag.Pointer(r200Ptr, "content", alt, "schema"))
+		if !ok {
+			return ir.Success{}, false
 		}
-		return ir.Success{Status: "200", Type: &typ}, nil
+		return ir.Success{Status: "200", Type: &typ}, true
 	}
 
+	mtPtr := diag.Pointer(r200Ptr, "content", mt)
 	if content[mt].Schema == nil {
-		return ir.Success{}, fmt.Errorf("responses[\"200\"] %q must define schema", mt)
+		n.diags.Reportf(diag.RuleResponseContent, mtPtr, "responses[\"200\"] %q must define schema", mt)
+		return ir.Success{}, false
 	}
 
-	typ, err := SchemaRefToTypeRef(content[mt].Schema)
-	if err != nil {
-		return ir.Success{}, fmt.Errorf("responses[\"200\"] %q schema: %w", mt, err)
+	typ, ok := n.schemaRefToTypeRef(content[mt].Schema, diag.Pointer(mtPtr, "schema"))
+	if !ok {
+		return ir.Success{}, false
 	}
 
 	return ir.Success{
 		Status: "200",
 		Type:   &typ,
-	}, nil
+	}, true
 }
 
 // findJSONContent tries to locate a content key that is effectively JSON.
@@ -3,57 +3,82 @@ package normalize
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 )
 
 // SchemaRefToTypeRef converts OpenAPI schema to our IR TypeRef.
 // disallow oneOf/anyOf/allOf/additionalProperties entirely.
 func SchemaRefToTypeRef(sr *openapi3.SchemaRef) (ir.TypeRef, error) {
+	n := &normalizer{diags: diag.NewCollector()}
+	tr, _ := n.schemaRefToTypeRef(sr, "")
+	if err := n.diags.Err(); err != nil {
+		return ir.TypeRef{}, err
+	}
+	return tr, nil
+}
+
+func (n *normalizer) schemaRefToTypeRef(sr *openapi3.SchemaRef, ptr string) (ir.TypeRef, bool) {
 	if sr == nil {
-		return ir.TypeRef{}, fmt.Errorf("schema is nil")
+		n.diags.Reportf(diag.RuleSchemaType, ptr, "schema is nil")
+		return ir.TypeRef{}, false
 	}
 
 	// $ref: only allow #/components/schemas/*
 	if sr.Ref != "" {
 		name, ok := refToComponentName(sr.Ref)
 		if !ok {
-			return ir.TypeRef{}, fmt.Errorf("only $ref to #/components/schemas/* is supported; got %q", sr.Ref)
+			n.diags.Reportf(diag.RuleSchemaRef, ptr, "only $ref to #/components/schemas/* is supported; got %q", sr.Ref)
+			return ir.TypeRef{}, false
 		}
-		return ir.TypeRef{RefName: name}, nil
+		return ir.TypeRef{RefName: name}, true
 	}
 
 	if sr.Value == nil {
-		return ir.TypeRef{}, fmt.Errorf("schema has no value")
+		n.diags.Reportf(diag.RuleSchemaType, ptr, "schema has no value")
+		return ir.TypeRef{}, false
 	}
 
-	t, err := schemaValueToType(sr.Value)
-	if err != nil {
-		return ir.TypeRef{}, err
+	t, ok := n.schemaValueToType(sr.Value, ptr)
+	if !ok {
+		return ir.TypeRef{}, false
 	}
-	return ir.TypeRef{Inline: &t}, nil
+	return ir.TypeRef{Inline: &t}, true
 }
 
-func schemaValueToType(s *openapi3.Schema) (ir.Type, error) {
+func (n *normalizer) schemaValueToType(s *openapi3.Schema, ptr string) (ir.Type, bool) {
 	if s == nil {
-		return ir.Type{}, fmt.Errorf("schema is nil")
+		n.diags.Reportf(diag.RuleSchemaType, ptr, "schema is nil")
+		return ir.Type{}, false
 	}
 
 	// Forbidden combinators & dynamic maps
-	if len(s.OneOf) > 0 {
-		return ir.Type{}, fmt.Errorf("oneOf is not supported")
-	}
-	if len(s.AnyOf) > 0 {
-		return ir.Type{}, fmt.Errorf("anyOf is not supported")
-	}
-	if len(s.AllOf) > 0 {
-		return ir.Type{}, fmt.Errorf("allOf is not supported")
+	ok := true
+	for _, c := range []struct {
+		kw   string
+		refs openapi3.SchemaRefs
+	}{{"oneOf", s.OneOf}, {"anyOf", s.AnyOf}, {"allOf", s.AllOf}} {
+		if len(c.refs) > 0 {
+			n.diags.Reportf(diag.RuleSchemaCombinator, diag.Pointer(ptr, c.kw), "%s is not supported", c.kw)
+			ok = false
+		}
 	}
 	if s.AdditionalProperties.Has != nil {
-		// additionalProperties can be boolean or schema; both disallowed
-		return ir.Type{}, fmt.Errorf("additionalProperties is not supported")
+		// boolean additionalProperties is disallowed
+		n.diags.Reportf(diag.RuleSchemaAdditionalProperty, diag.Pointer(ptr, "additionalProperties"), "additionalProperties is not supported")
+		ok = false
+	} else if s.AdditionalProperties.Schema != nil {
+		// the schema form has always been accepted and ignored; keep that,
+		// but say so
+		n.diags.Reportf(diag.RuleSchemaAdditionalPropertyIgnored, diag.Pointer(ptr, "additionalProperties"),
+			"additionalProperties is ignored; the generated type only has the declared properties")
+	}
+	if !ok {
+		return ir.Type{}, false
 	}
 
 	out := ir.Type{
@@ -63,57 +88,61 @@ func schemaValueToType(s *openapi3.Schema) (ir.Type, error) {
 	// Enum (support string enums only)
 	if len(s.Enum) > 0 {
 		vals := make([]string, 0, len(s.Enum))
-		for _, v := range s.Enum {
-			str, ok := v.(string)
-			if !ok {
-				return ir.Type{}, fmt.Errorf("enum must be string values only")
+		for i, v := range s.Enum {
+			str, isStr := v.(string)
+			if !isStr {
+				n.diags.Reportf(diag.RuleSchemaEnum, diag.Pointer(ptr, "enum", strconv.Itoa(i)), "enum must be string values only; got %v", v)
+				ok = false
+				continue
 			}
 			vals = append(vals, str)
 		}
 		out.Kind = ir.KindEnum
 		out.Enum = vals
-		return out, nil
+		return out, ok
 	}
 
 	typ, err := primaryType(s)
 	if err != nil {
-		return ir.Type{}, err
+		n.diags.Reportf(diag.RuleSchemaType, ptr, "%v", err)
+		return ir.Type{}, false
 	}
 
 	switch typ {
 	case "string":
 		out.Kind = ir.KindScalar
 		out.Scalar = "string"
-		return out, nil
+		return out, true
 	case "number":
 		out.Kind = ir.KindScalar
 		out.Scalar = "number"
-		return out, nil
+		return out, true
 	case "integer":
 		out.Kind = ir.KindScalar
 		out.Scalar = "integer"
-		return out, nil
+		return out, true
 	case "boolean":
 		out.Kind = ir.KindScalar
 		out.Scalar = "boolean"
-		return out, nil
+		return out, true
 	case "array":
 		if s.Items == nil {
-			return ir.Type{}, fmt.Errorf("array must define items")
+			n.diags.Reportf(diag.RuleSchemaArrayItems, ptr, "array must define items")
+			return ir.Type{}, false
 		}
-		elem, err := SchemaRefToTypeRef(s.Items)
-		if err != nil {
-			return ir.Type{}, fmt.Errorf("array items: %w", err)
+		elem, ok := n.schemaRefToTypeRef(s.Items, diag.Pointer(ptr, "items"))
+		if !ok {
+			return ir.Type{}, false
 		}
 		out.Kind = ir.KindArray
 		out.Elem = &elem
-		return out, nil
+		return out, true
 	case "object":
 		// object: only properties/required allowed
 		fields := make([]ir.Field, 0, len(s.Properties))
 		required := make(map[string]bool, len(s.Required))
-		for _, n := range s.Required {
-			required[n] = true
+		for _, r := range s.Required {
+			required[r] = true
 		}
 
 		names := make([]string, 0, len(s.Properties))
@@ -123,13 +152,17 @@ func schemaValueToType(s *openapi3.Schema) (ir.Type, error) {
 		sort.Strings(names)
 
 		for _, name := range names {
+			propPtr := diag.Pointer(ptr, "properties", name)
 			prop := s.Properties[name]
 			if prop == nil {
-				return ir.Type{}, fmt.Errorf("property %q schema is nil", name)
+				n.diags.Reportf(diag.RuleSchemaType, propPtr, "property %q schema is nil", name)
+				ok = false
+				continue
 			}
-			tr, err := SchemaRefToTypeRef(prop)
-			if err != nil {
-				return ir.Type{}, fmt.Errorf("property %q: %w", name, err)
+			tr, propOK := n.schemaRefToTypeRef(prop, propPtr)
+			if !propOK {
+				ok = false
+				continue
 			}
 			fields = append(fields, ir.Field{
 				Name:     name,
@@ -140,9 +173,11 @@ func schemaValueToType(s *openapi3.Schema) (ir.Type, error) {
 
 		out.Kind = ir.KindObject
 		out.Fields = fields
-		return out, nil
+		return out, ok
 	default:
-		return ir.Type{}, fmt.Errorf("schema type %q is not supported (must be object/array/string/number/integer/boolean or enum)", typ)
+		n.diags.Reportf(diag.RuleSchemaType, diag.Pointer(ptr, "type"),
+			"schema type %q is not supported (must be object/array/string/number/integer/boolean or enum)", typ)
+		return ir.Type{}, false
 	}
 }
 
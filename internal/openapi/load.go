@@ -2,26 +2,54 @@ package openapi
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 )
 
-func LoadAndValidate(specPath string) (*openapi3.T, error) {
+// LoadAndValidate loads the spec and reports problems to diags. It returns a
+// nil document when the spec cannot be normalized at all; violations that
+// don't prevent normalization (e.g. missing servers[0].url) are only reported.
+func LoadAndValidate(specPath string, diags *diag.Collector) (*openapi3.T, error) {
+	if diags == nil {
+		diags = diag.NewCollector()
+	}
+
+	// best effort: positions are optional, the loader reports real parse errors
+	if raw, err := os.ReadFile(specPath); err == nil {
+		if src, err := diag.ParseSource(specPath, raw); err == nil {
+			diags.SetSource(src)
+		}
+	}
+
 	loader := openapi3.NewLoader()
 	loader.IsExternalRefsAllowed = true
 
 	doc, err := loader.LoadFromFile(specPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+		diags.Reportf(diag.RuleSpecLoad, "", "failed to load OpenAPI spec: %v", err)
+		return nil, fatal(diags, fmt.Errorf("failed to load OpenAPI spec: %w", err))
 	}
 
 	if err := doc.Validate(loader.Context); err != nil {
-		return nil, fmt.Errorf("OpenAPI validation error: %w", err)
+		diags.Reportf(diag.RuleSpecInvalid, "", "OpenAPI validation error: %v", err)
+		return nil, fatal(diags, fmt.Errorf("OpenAPI validation error: %w", err))
 	}
 
-	if len(doc.Servers) == 0 || doc.Servers[0] == nil || doc.Servers[0].URL == "" {
-		return nil, fmt.Errorf("OpenAPI spec must define servers[0].url")
+	if len(doc.Servers) == 0 || doc.Servers[0] == nil || strings.TrimSpace(doc.Servers[0].URL) == "" {
+		diags.Reportf(diag.RuleServersURL, "/servers", "OpenAPI spec must define servers[0].url")
 	}
 
 	return doc, nil
 }
+
+// fatal prefers the collected diagnostics, but still fails when the rule that
+// stopped loading was configured below error severity.
+func fatal(diags *diag.Collector, err error) error {
+	if derr := diags.Err(); derr != nil {
+		return derr
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFiles returns specPath followed by every local file it references through
+// external $refs (transitively), sorted and de-duplicated. Remote refs are
+// skipped; referenced files that are missing or unparsable are still listed so
+// callers can watch for them to appear.
+func SpecFiles(specPath string) ([]string, error) {
+	root, err := filepath.Abs(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve spec path: %w", err)
+	}
+	if _, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("read spec: %w", err)
+	}
+
+	seen := map[string]bool{}
+	queue := []string{root}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			continue
+		}
+		for _, ref := range externalRefs(&node) {
+			queue = append(queue, filepath.Join(filepath.Dir(p), filepath.FromSlash(ref)))
+		}
+	}
+
+	files := make([]string, 0, len(seen))
+	for p := range seen {
+		if p != root {
+			files = append(files, p)
+		}
+	}
+	sort.Strings(files)
+	return append([]string{root}, files...), nil
+}
+
+// externalRefs collects the file part of every non-local, non-remote $ref.
+func externalRefs(n *yaml.Node) []string {
+	var out []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				k, v := n.Content[i], n.Content[i+1]
+				if k.Value == "$ref" && v.Kind == yaml.ScalarNode {
+					file, _, _ := strings.Cut(v.Value, "#")
+					if file != "" && !strings.Contains(file, "://") {
+						out = append(out, file)
+					}
+					continue
+				}
+				walk(v)
+			}
+			return
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(n)
+	return out
+}
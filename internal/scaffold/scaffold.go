@@ -0,0 +1,424 @@
+// Package scaffold writes hand-editable implementation stubs for the go-server
+// target. Unlike emitters it never overwrites code: existing files are only
+// appended to, with methods that the package does not define yet.
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/go/server"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+//go:embed templates/*.go.tpl
+var tplFS embed.FS
+
+type Options struct {
+	// Dir is the directory of the generated go-server package; stubs are
+	// written next to server.gen.go.
+	Dir string
+
+	// Package defaults to the package declared in Dir, else "server".
+	Package string
+
+	// MainPath is the main.go to create when missing ("" skips it).
+	MainPath string
+
+	// ImportPath of Dir, used by main.go; derived from the enclosing go.mod
+	// when empty.
+	ImportPath string
+
+	// Router is the go-server router option the package was generated with;
+	// main.go wires that router. "" reads it from the RegisterRoutes in Dir,
+	// else chi.
+	Router string
+}
+
+type Result struct {
+	Created []string
+	Updated []string
+
+	// Notes are follow-ups the scaffolder won't do itself, e.g. wiring a new
+	// service into an existing main.go.
+	Notes []string
+}
+
+// Go writes a <tag>_impl.go file per <Tag>Service with a <Tag>ServiceImpl
+// type whose methods return a "not implemented" RPCError, and optionally a
+// main.go that wires them into RegisterRoutes with opt.Router. Methods
+// already defined anywhere in the package are left alone.
+func Go(spec *ir.Spec, opt Options) (*Result, error) {
+	if opt.Dir == "" {
+		return nil, fmt.Errorf("output directory is required")
+	}
+	if opt.Router != "" && !slices.Contains(server.Routers, opt.Router) {
+		return nil, fmt.Errorf("unknown router %q (known: %s)", opt.Router, strings.Join(server.Routers, ", "))
+	}
+
+	pkg, err := scanPackage(opt.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Package == "" {
+		opt.Package = pkg.name
+	}
+	if opt.Package == "" {
+		opt.Package = "server"
+	}
+	if pkg.name != "" && pkg.name != opt.Package {
+		return nil, fmt.Errorf("%s declares package %s, not %s", opt.Dir, pkg.name, opt.Package)
+	}
+
+	data, err := server.BuildServerData(spec, opt.Package)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{}
+	if opt.Router == "" {
+		opt.Router = pkg.router
+	} else if pkg.router != "" && pkg.router != opt.Router && opt.MainPath != "" {
+		res.Notes = append(res.Notes, fmt.Sprintf("%s was generated for router %s; main.go uses %s", opt.Dir, pkg.router, opt.Router))
+	}
+	if opt.Router != "" {
+		data.Router = opt.Router
+	}
+	tpl, err := common.LoadTemplate(tplFS, "templates/impl.go.tpl", "", template.FuncMap{
+		"goMethodName": server.GoPublicIdent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tx common.Tx
+	var newTypes []string
+	for _, tag := range data.Tags {
+		implType := tag.Name + "ServiceImpl"
+		p := filepath.Join(opt.Dir, fileName(tag.Name)+"_impl.go")
+
+		var missing []server.GoRoute
+		for _, r := range tag.Routes {
+			if !pkg.methods[implType][server.GoPublicIdent(r.Name)] {
+				missing = append(missing, r)
+			}
+		}
+		declared := pkg.types[implType]
+		if declared && len(missing) == 0 {
+			continue
+		}
+
+		var stub bytes.Buffer
+		if !declared {
+			newTypes = append(newTypes, tag.Name)
+			if err := tpl.ExecuteTemplate(&stub, "type", tag); err != nil {
+				return nil, fmt.Errorf("exec template: %w", err)
+			}
+		}
+		if len(missing) > 0 {
+			if err := tpl.ExecuteTemplate(&stub, "methods", server.GoTag{Name: tag.Name, Routes: missing}); err != nil {
+				return nil, fmt.Errorf("exec template: %w", err)
+			}
+		}
+
+		src, exists := pkg.files[p]
+		if !exists {
+			var header bytes.Buffer
+			if err := tpl.ExecuteTemplate(&header, "header", data); err != nil {
+				return nil, fmt.Errorf("exec template: %w", err)
+			}
+			src = header.Bytes()
+		} else {
+			src = ensureImports(src, "context", "net/http")
+		}
+		out, err := format.Source(append(append(bytes.TrimRight(src, "\n"), '\n'), stub.Bytes()...))
+		if err != nil {
+			return nil, fmt.Errorf("format %s: %w", p, err)
+		}
+		tx.Write(p, out)
+		if exists {
+			res.Updated = append(res.Updated, p)
+		} else {
+			res.Created = append(res.Created, p)
+		}
+	}
+
+	if opt.MainPath != "" {
+		if _, err := os.Stat(opt.MainPath); err == nil {
+			for _, t := range newTypes {
+				res.Notes = append(res.Notes, fmt.Sprintf("%s exists; add %s: &%s.%sServiceImpl{} to %s.Services", opt.MainPath, t, opt.Package, t, opt.Package))
+			}
+		} else {
+			out, err := renderMain(data, opt)
+			if err != nil {
+				return nil, err
+			}
+			tx.Write(opt.MainPath, out)
+			res.Created = append(res.Created, opt.MainPath)
+		}
+	}
+
+	if _, err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func renderMain(data *server.ServerTemplateData, opt Options) ([]byte, error) {
+	importPath := opt.ImportPath
+	if importPath == "" {
+		p, err := importPathOf(opt.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("main.go: %w (set the import path explicitly)", err)
+		}
+		importPath = p
+	}
+	importName := ""
+	if path.Base(importPath) != data.Package {
+		importName = data.Package
+	}
+
+	tpl, err := common.LoadTemplate(tplFS, "templates/main.go.tpl", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	out, err := common.ExecTemplate(tpl, struct {
+		*server.ServerTemplateData
+		ImportPath string
+		ImportName string
+	}{data, importPath, importName})
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, fmt.Errorf("format %s: %w", opt.MainPath, err)
+	}
+	return formatted, nil
+}
+
+type goPackage struct {
+	name    string
+	files   map[string][]byte
+	types   map[string]bool
+	methods map[string]map[string]bool // receiver type -> method names
+	router  string                     // go-server router of RegisterRoutes, if declared
+}
+
+// scanPackage parses the non-test Go files in dir (which may not exist yet).
+func scanPackage(dir string) (*goPackage, error) {
+	pkg := &goPackage{
+		files:   map[string][]byte{},
+		types:   map[string]bool{},
+		methods: map[string]map[string]bool{},
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return pkg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read dir: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		p := filepath.Join(dir, name)
+		src, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", p, err)
+		}
+		f, err := parser.ParseFile(fset, p, src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", p, err)
+		}
+		pkg.files[p] = src
+		if pkg.name == "" {
+			pkg.name = f.Name.Name
+		}
+
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						pkg.types[ts.Name.Name] = true
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil && d.Name.Name == "RegisterRoutes" {
+					pkg.router = routerOf(d)
+				}
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					continue
+				}
+				recv := receiverType(d.Recv.List[0].Type)
+				if pkg.methods[recv] == nil {
+					pkg.methods[recv] = map[string]bool{}
+				}
+				pkg.methods[recv][d.Name.Name] = true
+			}
+		}
+	}
+	return pkg, nil
+}
+
+// routerOf maps the first parameter of a generated RegisterRoutes to the
+// go-server router option that produced it.
+func routerOf(fn *ast.FuncDecl) string {
+	params := fn.Type.Params.List
+	if len(params) == 0 {
+		return ""
+	}
+	switch types.ExprString(params[0].Type) {
+	case "*http.ServeMux":
+		return "stdlib"
+	case "gin.IRouter":
+		return "gin"
+	case "*echo.Group":
+		return "echo"
+	case "chi.Router":
+		return "chi"
+	}
+	return ""
+}
+
+func receiverType(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StarExpr:
+		return receiverType(t.X)
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
+
+// ensureImports adds an import declaration for each path src doesn't import
+// unnamed, right after its last import (or the package clause), leaving the
+// rest of src untouched.
+func ensureImports(src []byte, paths ...string) []byte {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return src
+	}
+
+	have := map[string]bool{}
+	for _, imp := range f.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name == nil {
+			have[p] = true
+		}
+	}
+	var add []string
+	for _, p := range paths {
+		if !have[p] {
+			add = append(add, fmt.Sprintf("import %q\n", p))
+		}
+	}
+	if len(add) == 0 {
+		return src
+	}
+	sort.Strings(add)
+
+	at := f.Name.End()
+	for _, d := range f.Decls {
+		if gd, ok := d.(*ast.GenDecl); ok && gd.Tok == token.IMPORT {
+			at = gd.End()
+		}
+	}
+	off := fset.Position(at).Offset
+
+	var out bytes.Buffer
+	out.Write(src[:off])
+	out.WriteString("\n\n")
+	out.WriteString(strings.Join(add, ""))
+	out.Write(src[off:])
+	return out.Bytes()
+}
+
+// fileName turns a Go identifier into a snake_case file name: "UserAdmin" ->
+// "user_admin".
+func fileName(ident string) string {
+	var b strings.Builder
+	runes := []rune(ident)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// importPathOf derives the import path of dir from the enclosing go.mod.
+func importPathOf(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		mod, err := modulePath(filepath.Join(root, "go.mod"))
+		if err == nil {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return mod, nil
+			}
+			return mod + "/" + filepath.ToSlash(rel), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("no go.mod found above %s", dir)
+		}
+	}
+}
+
+func modulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if rest, ok := strings.CutPrefix(line, "module"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s: missing module directive", goMod)
+}
@@ -0,0 +1,25 @@
+{{- define "header" -}}
+package {{ .Package }}
+
+import (
+	"context"
+	"net/http"
+)
+{{ end }}
+
+{{- define "type" }}
+// {{ .Name }}ServiceImpl implements {{ .Name }}Service.
+type {{ .Name }}ServiceImpl struct{}
+
+var _ {{ .Name }}Service = (*{{ .Name }}ServiceImpl)(nil)
+{{ end }}
+
+{{- define "methods" }}
+{{- range .Routes }}
+
+func (s *{{ .TagName }}ServiceImpl) {{ .ServiceMethod }} {
+	var resp {{ .RespType }}
+	return resp, &RPCError{Status: http.StatusNotImplemented, Message: "{{ goMethodName .Name }} is not implemented"}
+}
+{{- end }}
+{{ end }}
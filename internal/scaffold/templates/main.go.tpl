@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log"
+	"net/http"
+{{- if eq .Router "gin" }}
+
+	"github.com/gin-gonic/gin"
+{{- else if eq .Router "echo" }}
+
+	"github.com/labstack/echo/v4"
+{{- else if eq .Router "chi" }}
+
+	"github.com/go-chi/chi/v5"
+{{- end }}
+
+	{{ if .ImportName }}{{ .ImportName }} {{ end }}"{{ .ImportPath }}"
+)
+
+func main() {
+{{- if eq .Router "stdlib" }}
+	r := http.NewServeMux()
+	{{ .Package }}.RegisterRoutes(r, {{ .Package }}.Services{
+{{- else if eq .Router "gin" }}
+	r := gin.Default()
+	// route escaped path values (a%2Fb) like the other routers do
+	r.UseRawPath = true
+	r.UnescapePathValues = false
+	{{ .Package }}.RegisterRoutes(r, {{ .Package }}.Services{
+{{- else if eq .Router "echo" }}
+	r := echo.New()
+	{{ .Package }}.RegisterRoutes(r.Group(""), {{ .Package }}.Services{
+{{- else }}
+	r := chi.NewRouter()
+	{{ .Package }}.RegisterRoutes(r, {{ .Package }}.Services{
+{{- end }}
+	{{- range .Tags }}
+		{{ .Name }}: &{{ $.Package }}.{{ .Name }}ServiceImpl{},
+	{{- end }}
+	})
+
+	addr := ":8080"
+	log.Printf("listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, r))
+}
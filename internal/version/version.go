@@ -0,0 +1,20 @@
+// Package version reports the generator version recorded in manifests.
+package version
+
+import "runtime/debug"
+
+// Version can be set at build time:
+//
+//	go build -ldflags "-X github.com/xxxbrian/openapi-rpc-codegen/internal/version.Version=v1.2.3"
+var Version = ""
+
+// String returns Version, falling back to the module version from build info.
+func String() string {
+	if Version != "" {
+		return Version
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "" {
+		return bi.Main.Version
+	}
+	return "(devel)"
+}
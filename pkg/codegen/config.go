@@ -0,0 +1,232 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileNames are looked up (in order) in the working directory when the
+// CLI runs without -spec.
+var ConfigFileNames = []string{"openapi-rpc-codegen.yaml", "openapi-rpc-codegen.yml"}
+
+// ConfigVersion is the only supported value of Config.Version.
+const ConfigVersion = 1
+
+// Config is the project config file (openapi-rpc-codegen.yaml):
+//
+//	version: 1
+//	templates: ./codegen-templates   # optional, see Options.TemplatesDir
+//	specs:
+//	  - spec: api/openapi.yaml
+//	    baseUrl: https://api.example.com   # optional, overrides servers[0].url
+//	    out: generated                     # optional, default root for targets
+//	    targets:
+//	      - target: go-server
+//	        out: internal/api
+//	        package: api
+//	      - target: ts-wx
+//	        out: miniprogram/api
+//	        clientFactory: makeUserApi
+//	      - target: plugin:kotlin
+//	        options:
+//	          package: com.example.api
+//
+// Relative paths are resolved against the directory of the config file.
+type Config struct {
+	Version   int          `yaml:"version"`
+	Templates string       `yaml:"templates,omitempty"`
+	Specs     []SpecConfig `yaml:"specs"`
+}
+
+type SpecConfig struct {
+	Spec    string         `yaml:"spec"`
+	BaseURL string         `yaml:"baseUrl,omitempty"`
+	Out     string         `yaml:"out,omitempty"`
+	Targets []TargetConfig `yaml:"targets"`
+}
+
+type TargetConfig struct {
+	Target string `yaml:"target"`
+
+	// Out defaults to <spec out>/<target>.
+	Out string `yaml:"out,omitempty"`
+
+	// Shorthands for common emitter options; they are merged into Options.
+	Package       string `yaml:"package,omitempty"`
+	ClientFactory string `yaml:"clientFactory,omitempty"`
+
+	Options map[string]string `yaml:"options,omitempty"`
+}
+
+// FindConfig returns the first of ConfigFileNames present in dir, or "".
+func FindConfig(dir string) string {
+	for _, name := range ConfigFileNames {
+		p := filepath.Join(dir, name)
+		if st, err := os.Stat(p); err == nil && !st.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+// LoadConfig reads and validates a config file, resolving relative paths
+// against its directory.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	cfg.resolvePaths(filepath.Dir(path))
+	return cfg, nil
+}
+
+// ParseConfig decodes and validates a config document. Unknown fields are
+// rejected; paths are left as written.
+func ParseConfig(data []byte) (*Config, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("config is empty")
+		}
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks required fields, target names and emitter options, and
+// reports every problem found.
+func (c *Config) Validate() error {
+	var errs []string
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, field+": "+fmt.Sprintf(format, args...))
+	}
+
+	if c.Version != ConfigVersion {
+		fail("version", "must be %d, got %d", ConfigVersion, c.Version)
+	}
+	if len(c.Specs) == 0 {
+		fail("specs", "at least one spec is required")
+	}
+
+	for i, s := range c.Specs {
+		sf := fmt.Sprintf("specs[%d]", i)
+		if strings.TrimSpace(s.Spec) == "" {
+			fail(sf+".spec", "is required")
+		}
+		if len(s.Targets) == 0 {
+			fail(sf+".targets", "at least one target is required")
+		}
+
+		seen := map[string]int{}
+		for j, t := range s.Targets {
+			tf := fmt.Sprintf("%s.targets[%d]", sf, j)
+			if strings.TrimSpace(t.Target) == "" {
+				fail(tf+".target", "is required (known: %s, plugin:<name>)", strings.Join(emit.Targets(), ", "))
+				continue
+			}
+			if prev, dup := seen[t.Target]; dup {
+				fail(tf+".target", "%q is already configured at targets[%d]", t.Target, prev)
+				continue
+			}
+			seen[t.Target] = j
+
+			e, ok := emit.Lookup(t.Target)
+			if !ok {
+				fail(tf+".target", "unknown target %q (known: %s, plugin:<name>)", t.Target, strings.Join(emit.Targets(), ", "))
+				continue
+			}
+			if err := emit.ValidateOptions(e, t.options()); err != nil {
+				fail(tf, "%v", err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// Options converts the config to one Options per spec entry.
+func (c *Config) Options() []Options {
+	out := make([]Options, 0, len(c.Specs))
+	for _, s := range c.Specs {
+		root := s.Out
+		if root == "" {
+			root = "."
+		}
+		opts := Options{
+			SpecPath:      s.Spec,
+			OutDir:        root,
+			BaseURL:       s.BaseURL,
+			TemplatesDir:  c.Templates,
+			TargetOptions: map[string]map[string]string{},
+			TargetOutDirs: map[string]string{},
+		}
+		for _, t := range s.Targets {
+			opts.Targets = append(opts.Targets, t.Target)
+			if o := t.options(); len(o) > 0 {
+				opts.TargetOptions[t.Target] = o
+			}
+			if t.Out != "" {
+				opts.TargetOutDirs[t.Target] = t.Out
+			}
+		}
+		out = append(out, opts)
+	}
+	return out
+}
+
+func (t TargetConfig) options() map[string]string {
+	out := map[string]string{}
+	for k, v := range t.Options {
+		out[k] = v
+	}
+	if t.Package != "" {
+		out["package"] = t.Package
+	}
+	if t.ClientFactory != "" {
+		out["clientFactory"] = t.ClientFactory
+	}
+	return out
+}
+
+func (c *Config) resolvePaths(base string) {
+	abs := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(base, p)
+	}
+	c.Templates = abs(c.Templates)
+	for i := range c.Specs {
+		s := &c.Specs[i]
+		s.Spec = abs(s.Spec)
+		if s.Out == "" {
+			s.Out = base
+		} else {
+			s.Out = abs(s.Out)
+		}
+		for j := range s.Targets {
+			s.Targets[j].Out = abs(s.Targets[j].Out)
+		}
+	}
+}
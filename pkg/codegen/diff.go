@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/irdiff"
+)
+
+type (
+	SpecChange = irdiff.Change
+	DiffReport = irdiff.Report
+	Bump       = irdiff.Bump
+)
+
+const (
+	BumpNone  = irdiff.BumpNone
+	BumpMinor = irdiff.BumpMinor
+	BumpMajor = irdiff.BumpMajor
+)
+
+type DiffOptions struct {
+	OldSpecPath string
+	NewSpecPath string
+}
+
+// Diff normalizes both specs and classifies every API change as breaking or
+// not for clients generated from the old spec.
+func Diff(opts DiffOptions) (*DiffReport, error) {
+	if opts.OldSpecPath == "" || opts.NewSpecPath == "" {
+		return nil, fmt.Errorf("old and new spec paths are required")
+	}
+	old, err := loadIR(opts.OldSpecPath, "", diag.NewCollector())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opts.OldSpecPath, err)
+	}
+	new, err := loadIR(opts.NewSpecPath, "", diag.NewCollector())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", opts.NewSpecPath, err)
+	}
+	return irdiff.Compare(old, new), nil
+}
+
+// DiffIR compares two already normalized specs (e.g. read with LoadIR).
+func DiffIR(old, new *Spec) *DiffReport {
+	return irdiff.Compare(old, new)
+}
@@ -0,0 +1,34 @@
+package codegen
+
+import (
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/common"
+)
+
+type (
+	// Emitter renders one target from the normalized spec. Returned file paths
+	// are slash-separated and relative to the target's output directory
+	// (Options.TargetOutDirs, default OutDir/<target>); Generate writes them,
+	// so --check and atomic writes apply to custom targets too.
+	Emitter = emit.Emitter
+
+	// EmitOptions carries the target's entry from Options.TargetOptions.
+	EmitOptions = emit.EmitOptions
+
+	File = common.File
+)
+
+// Register makes e available as a target for Generate. The built-in targets
+// (see Targets) are registered the same way. It panics if the name is empty,
+// starts with "plugin:" or is already registered.
+func Register(e Emitter) {
+	emit.Register(e)
+}
+
+// Targets returns the registered target names: the built-in raw-ir, ts-wx,
+// ts-mp, ts-fetch, ts-server, go-server, go-client, python-client,
+// kotlin-client, swift-client and dart-client, plus any added with Register.
+// "plugin:<name>" targets are resolved at generation time and are not listed.
+func Targets() []string {
+	return emit.Targets()
+}
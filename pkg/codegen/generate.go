@@ -3,7 +3,9 @@ package codegen
 import (
 	"fmt"
 
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/normalize"
 	"github.com/xxxbrian/openapi-rpc-codegen/internal/openapi"
 )
@@ -16,28 +18,42 @@ func Generate(opts Options) (*Result, error) {
 		return nil, fmt.Errorf("output directory is required")
 	}
 
-	doc, err := openapi.LoadAndValidate(opts.SpecPath)
+	diags := diag.NewCollector()
+	irSpec, err := loadIR(opts.SpecPath, opts.BaseURL, diags)
 	if err != nil {
 		return nil, err
 	}
 
-	irSpec, err := normalize.ToIR(doc, normalize.Options{
-		BaseURLOverride: opts.BaseURL,
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	files, err := emit.Dispatch(irSpec, emit.Options{
+	report, err := emit.Dispatch(irSpec, emit.Options{
 		OutDir:  opts.OutDir,
 		Targets: opts.Targets,
 		Check:   opts.Check,
 		Verbose: opts.Verbose,
+
+		TemplatesDir:  opts.TemplatesDir,
+		TargetOptions: opts.TargetOptions,
+		TargetOutDirs: opts.TargetOutDirs,
+		Force:         opts.Force,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Result{Files: files}, nil
+	return &Result{
+		Files:       report.Written,
+		Deleted:     report.Deleted,
+		Diagnostics: diags.Diagnostics(),
+	}, nil
+}
+
+// loadIR loads, validates and normalizes a spec, reporting to diags.
+func loadIR(specPath, baseURL string, diags *diag.Collector) (*ir.Spec, error) {
+	doc, err := openapi.LoadAndValidate(specPath, diags)
+	if err != nil {
+		return nil, err
+	}
+	return normalize.ToIR(doc, normalize.Options{
+		BaseURLOverride: baseURL,
+		Diagnostics:     diags,
+	})
 }
@@ -0,0 +1,59 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+// The normalized API model, as written by the raw-ir target.
+type (
+	Spec      = ir.Spec
+	Meta      = ir.Meta
+	ErrorCode = ir.ErrorCode
+	Route     = ir.Route
+	Param     = ir.Param
+	Body      = ir.Body
+	Success   = ir.Success
+	TypeDecl  = ir.TypeDecl
+	TypeRef   = ir.TypeRef
+	TypeKind  = ir.TypeKind
+	Type      = ir.Type
+	Field     = ir.Field
+)
+
+const (
+	KindScalar = ir.KindScalar
+	KindObject = ir.KindObject
+	KindArray  = ir.KindArray
+	KindEnum   = ir.KindEnum
+)
+
+// IRFormatVersion is the raw-ir document version understood by ReadIR.
+const IRFormatVersion = ir.FormatVersion
+
+// ReadIR decodes a raw-ir JSON document.
+func ReadIR(r io.Reader) (*Spec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read IR: %w", err)
+	}
+	return ir.Unmarshal(data)
+}
+
+// LoadIR reads a raw-ir JSON document from path (e.g. <out>/raw-ir/ir.json).
+func LoadIR(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open IR: %w", err)
+	}
+	defer f.Close()
+	return ReadIR(f)
+}
+
+// MarshalIR encodes spec as a raw-ir JSON document.
+func MarshalIR(spec *Spec) ([]byte, error) {
+	return ir.Marshal(spec)
+}
@@ -0,0 +1,69 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/normalize"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/openapi"
+)
+
+type (
+	Diagnostic = diag.Diagnostic
+	Severity   = diag.Severity
+)
+
+const (
+	SeverityOff     = diag.SeverityOff
+	SeverityWarning = diag.SeverityWarning
+	SeverityError   = diag.SeverityError
+)
+
+type LintOptions struct {
+	SpecPath string
+	BaseURL  string
+
+	// Severities overrides the default severity per rule ID.
+	Severities map[string]Severity
+}
+
+// LintRules returns every rule ID with its default severity.
+func LintRules() map[string]Severity {
+	out := map[string]Severity{}
+	for _, r := range diag.Rules() {
+		out[r], _ = diag.DefaultSeverity(r)
+	}
+	return out
+}
+
+// Lint loads and normalizes the spec, collecting every diagnostic instead of
+// stopping at the first violation. The error is non-nil for invalid options,
+// and when the spec cannot be loaded but spec-load or spec-invalid is off.
+func Lint(opts LintOptions) ([]Diagnostic, error) {
+	if opts.SpecPath == "" {
+		return nil, fmt.Errorf("spec path is required")
+	}
+
+	diags := diag.NewCollector()
+	for rule, sev := range opts.Severities {
+		if err := diags.SetSeverity(rule, sev); err != nil {
+			return nil, err
+		}
+	}
+
+	doc, err := openapi.LoadAndValidate(opts.SpecPath, diags)
+	if err != nil {
+		// with the load rules off, nothing recorded the failure
+		if !diags.HasErrors() {
+			return nil, err
+		}
+		return diags.Diagnostics(), nil
+	}
+	// failures are already recorded in diags
+	_, _ = normalize.ToIR(doc, normalize.Options{
+		BaseURLOverride: opts.BaseURL,
+		Diagnostics:     diags,
+	})
+
+	return diags.Diagnostics(), nil
+}
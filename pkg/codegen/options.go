@@ -7,4 +7,20 @@ type Options struct {
 	Targets  []string
 	Check    bool
 	Verbose  bool
+
+	// TemplatesDir overrides built-in templates by file name (e.g.
+	// "server.go.tpl"); see README for the overridable blocks.
+	TemplatesDir string
+
+	// TargetOptions holds per-target options keyed by target name
+	// (e.g. "go-server", "plugin:kotlin").
+	TargetOptions map[string]map[string]string
+
+	// TargetOutDirs overrides the output directory per target
+	// (default: OutDir/<target>, OutDir/<name> for "plugin:<name>").
+	TargetOutDirs map[string]string
+
+	// Force overwrites and prunes generated files even if they were edited by
+	// hand since the last run (see the per-directory manifest).
+	Force bool
 }
@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/emit/plugin"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/ir"
+)
+
+// Plugin protocol types. A target "plugin:<name>" runs the executable
+// "openapi-rpc-codegen-gen-<name>", writes a PluginRequest to its stdin and
+// reads a PluginResponse from its stdout.
+type (
+	PluginRequest  = plugin.Request
+	PluginResponse = plugin.Response
+	PluginFile     = plugin.File
+)
+
+// PluginFunc generates files from the normalized spec and the plugin options.
+type PluginFunc func(spec *Spec, options map[string]string) ([]PluginFile, error)
+
+// RunPlugin implements the plugin side of the protocol on stdin/stdout.
+// Plugin executables typically call it from main:
+//
+//	func main() {
+//		if err := codegen.RunPlugin(generate); err != nil {
+//			os.Exit(1)
+//		}
+//	}
+func RunPlugin(fn PluginFunc) error {
+	return ServePlugin(os.Stdin, os.Stdout, fn)
+}
+
+// ServePlugin is RunPlugin with explicit streams. Generation errors are
+// reported to the host in the response and also returned.
+func ServePlugin(r io.Reader, w io.Writer, fn PluginFunc) error {
+	var req PluginRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("decode plugin request: %w", err)
+	}
+	if req.Version != plugin.ProtocolVersion {
+		return fmt.Errorf("unsupported plugin protocol version %d (expected %d)", req.Version, plugin.ProtocolVersion)
+	}
+
+	var resp PluginResponse
+	spec, genErr := ir.Unmarshal(req.IR)
+	if genErr == nil {
+		resp.Files, genErr = fn(spec, req.Options)
+	}
+	if genErr != nil {
+		resp = PluginResponse{Error: genErr.Error()}
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		return fmt.Errorf("encode plugin response: %w", err)
+	}
+	return genErr
+}
@@ -1,5 +1,28 @@
 package codegen
 
+import "github.com/xxxbrian/openapi-rpc-codegen/internal/emit"
+
+// CheckError is returned by Generate in check mode when outputs drifted. Its
+// message contains a unified diff per changed file and a summary line.
+type (
+	CheckError = emit.CheckError
+	Drift      = emit.Drift
+	DriftKind  = emit.DriftKind
+)
+
+const (
+	DriftChanged  = emit.DriftChanged
+	DriftMissing  = emit.DriftMissing
+	DriftOrphaned = emit.DriftOrphaned
+)
+
 type Result struct {
 	Files []string
+
+	// Deleted lists previously generated files pruned because the spec no
+	// longer produces them.
+	Deleted []string
+
+	// Diagnostics holds non-fatal findings (warnings) from a successful run.
+	Diagnostics []Diagnostic
 }
@@ -0,0 +1,69 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/diag"
+	"github.com/xxxbrian/openapi-rpc-codegen/internal/scaffold"
+)
+
+type ScaffoldOptions struct {
+	SpecPath string
+	BaseURL  string
+
+	// Dir is the go-server output directory the stubs are written to.
+	Dir string
+
+	// Package defaults to the package already declared in Dir, else "server".
+	Package string
+
+	// MainPath is the main.go to create if it doesn't exist ("" skips it).
+	MainPath string
+
+	// ImportPath of Dir for main.go; derived from the enclosing go.mod when empty.
+	ImportPath string
+
+	// Router is the go-server "router" option Dir was generated with;
+	// main.go wires the matching router. Empty means the one the generated
+	// RegisterRoutes in Dir takes, else chi.
+	Router string
+}
+
+type ScaffoldResult struct {
+	Created     []string
+	Updated     []string
+	Notes       []string
+	Diagnostics []Diagnostic
+}
+
+// Scaffold writes <tag>_impl.go stubs for every <Tag>Service and, optionally,
+// a main.go wiring them up. It never overwrites code: later runs only append
+// methods for operations the package doesn't implement yet.
+func Scaffold(opts ScaffoldOptions) (*ScaffoldResult, error) {
+	if opts.SpecPath == "" {
+		return nil, fmt.Errorf("spec path is required")
+	}
+
+	diags := diag.NewCollector()
+	irSpec, err := loadIR(opts.SpecPath, opts.BaseURL, diags)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := scaffold.Go(irSpec, scaffold.Options{
+		Dir:        opts.Dir,
+		Package:    opts.Package,
+		MainPath:   opts.MainPath,
+		ImportPath: opts.ImportPath,
+		Router:     opts.Router,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ScaffoldResult{
+		Created:     res.Created,
+		Updated:     res.Updated,
+		Notes:       res.Notes,
+		Diagnostics: diags.Diagnostics(),
+	}, nil
+}
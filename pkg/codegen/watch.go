@@ -0,0 +1,9 @@
+package codegen
+
+import "github.com/xxxbrian/openapi-rpc-codegen/internal/openapi"
+
+// SpecFiles returns the spec file followed by every local file it pulls in
+// through external $refs, i.e. the files whose changes affect Generate.
+func SpecFiles(specPath string) ([]string, error) {
+	return openapi.SpecFiles(specPath)
+}